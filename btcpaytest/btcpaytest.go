@@ -0,0 +1,330 @@
+// Package btcpaytest implements an in-process stand-in for a BTCPay Server store.
+//
+// It serves the parts of the Greenfield API which are used by go-btcpay, lets the caller drive invoices through their states and sends signed webhooks, so the purchase flow can be run offline.
+package btcpaytest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/dys2p/go-btcpay"
+)
+
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
+type Server struct {
+	WebhookURL  string // full URL of the webhook receiver, e.g. http://127.0.0.1:9002/payment/btcpay/webhook
+	WebhookHost string // optional, overrides the Host header of webhook requests, for receivers which serve several host names
+
+	lock       sync.Mutex
+	httpServer *httptest.Server
+	invoices   map[string]*btcpay.Invoice
+	store      btcpay.Store
+}
+
+// NewServer starts a Server on a local port. The caller should call Close when finished.
+func NewServer(webhookURL string) *Server {
+	s := &Server{
+		WebhookURL: webhookURL,
+		invoices:   make(map[string]*btcpay.Invoice),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/stores/{store}/invoices", s.auth(s.createInvoice))
+	mux.HandleFunc("GET /api/v1/stores/{store}/invoices/{invoice}", s.auth(s.getInvoice))
+	mux.HandleFunc("GET /api/v1/stores/{store}/invoices/{invoice}/payment-methods", s.auth(s.getPaymentMethods))
+	mux.HandleFunc("GET /api/v1/server/info", s.auth(s.getServerInfo))
+	mux.HandleFunc("GET /i/{invoice}", s.checkoutGet)
+	mux.HandleFunc("POST /i/{invoice}", s.checkoutPost)
+
+	s.httpServer = httptest.NewServer(mux)
+	s.store = btcpay.Store{
+		Host:          s.httpServer.URL,
+		ID:            "teststore",
+		UserAPIKey:    randomHex(),
+		WebhookSecret: randomHex(),
+	}
+	return s
+}
+
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Store returns a store configuration which points to s.
+func (s *Server) Store() btcpay.Store {
+	return s.store
+}
+
+// Invoice returns a copy of the invoice with the given ID.
+func (s *Server) Invoice(id string) (btcpay.Invoice, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	invoice, ok := s.invoices[id]
+	if !ok {
+		return btcpay.Invoice{}, false
+	}
+	return *invoice, true
+}
+
+// InvoiceByOrderID returns a copy of the most recent invoice with the given order ID.
+func (s *Server) InvoiceByOrderID(orderID string) (btcpay.Invoice, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var result *btcpay.Invoice
+	for _, invoice := range s.invoices {
+		if invoice.OrderID == orderID && (result == nil || invoice.CreatedTime > result.CreatedTime) {
+			result = invoice
+		}
+	}
+	if result == nil {
+		return btcpay.Invoice{}, false
+	}
+	return *result, true
+}
+
+// SetProcessing marks the invoice as fully paid but unconfirmed and sends an InvoiceProcessing webhook.
+func (s *Server) SetProcessing(invoiceID string) error {
+	return s.setStatus(invoiceID, btcpay.InvoiceProcessing, btcpay.EventInvoiceProcessing)
+}
+
+// SetSettled marks the invoice as paid and confirmed and sends an InvoiceSettled webhook.
+func (s *Server) SetSettled(invoiceID string) error {
+	return s.setStatus(invoiceID, btcpay.InvoiceSettled, btcpay.EventInvoiceSettled)
+}
+
+// SetExpired marks the invoice as expired and sends an InvoiceExpired webhook.
+func (s *Server) SetExpired(invoiceID string) error {
+	return s.setStatus(invoiceID, btcpay.InvoiceExpired, btcpay.EventInvoiceExpired)
+}
+
+// Redeliver sends the webhook of the current invoice status again, like BTCPay Server does if automatic redelivery is enabled.
+func (s *Server) Redeliver(invoiceID string) error {
+	s.lock.Lock()
+	invoice, ok := s.invoices[invoiceID]
+	if !ok {
+		s.lock.Unlock()
+		return ErrInvoiceNotFound
+	}
+	var eventType btcpay.EventType
+	switch invoice.Status {
+	case btcpay.InvoiceProcessing:
+		eventType = btcpay.EventInvoiceProcessing
+	case btcpay.InvoiceSettled:
+		eventType = btcpay.EventInvoiceSettled
+	case btcpay.InvoiceExpired:
+		eventType = btcpay.EventInvoiceExpired
+	default:
+		s.lock.Unlock()
+		return fmt.Errorf("invoice %s has status %s, nothing to redeliver", invoiceID, invoice.Status)
+	}
+	event := s.makeEvent(invoice, eventType)
+	s.lock.Unlock()
+
+	event.IsRedelivery = true
+	return s.SendWebhook(event)
+}
+
+func (s *Server) setStatus(invoiceID, status string, eventType btcpay.EventType) error {
+	s.lock.Lock()
+	invoice, ok := s.invoices[invoiceID]
+	if !ok {
+		s.lock.Unlock()
+		return ErrInvoiceNotFound
+	}
+	invoice.Status = status
+	event := s.makeEvent(invoice, eventType)
+	s.lock.Unlock()
+
+	return s.SendWebhook(event)
+}
+
+// must be called with s.lock held
+func (s *Server) makeEvent(invoice *btcpay.Invoice, eventType btcpay.EventType) btcpay.InvoiceEvent {
+	return btcpay.InvoiceEvent{
+		DeliveryID:      randomHex(),
+		InvoiceID:       invoice.ID,
+		StoreID:         s.store.ID,
+		Timestamp:       time.Now().Unix(),
+		Type:            eventType,
+		WebhookID:       "testwebhook",
+		InvoiceMetadata: invoice.InvoiceMetadata,
+	}
+}
+
+// SendWebhook posts the event to s.WebhookURL and signs it like BTCPay Server does. It returns an error if the receiver does not respond with a 2xx status code.
+func (s *Server) SendWebhook(event btcpay.InvoiceEvent) error {
+	if s.WebhookURL == "" {
+		return errors.New("missing webhook URL")
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(s.store.WebhookSecret))
+	mac.Write(body)
+
+	req, err := http.NewRequest(http.MethodPost, s.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if s.WebhookHost != "" {
+		req.Host = s.WebhookHost
+	}
+	req.Header.Set("BTCPay-Sig", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return fmt.Errorf("sending webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook response status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// middleware which checks the API key and the store ID
+func (s *Server) auth(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token "+s.store.UserAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if store := r.PathValue("store"); store != "" && store != s.store.ID {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		f(w, r)
+	}
+}
+
+func (s *Server) createInvoice(w http.ResponseWriter, r *http.Request) {
+	var req *btcpay.InvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req == nil || req.Amount <= 0 || req.Currency == "" {
+		w.WriteHeader(http.StatusBadRequest) // CheckInvoiceAuth relies on this
+		return
+	}
+
+	now := time.Now()
+	invoice := &btcpay.Invoice{
+		InvoiceRequest: *req,
+		ID:             randomHex()[:22],
+		CreatedTime:    now.UnixNano(), // nanoseconds make InvoiceByOrderID deterministic
+		ExpirationTime: now.Add(time.Hour).Unix(),
+		Status:         btcpay.InvoiceNew,
+	}
+	invoice.CheckoutLink = s.store.InvoiceCheckoutLink(invoice.ID, false)
+
+	s.lock.Lock()
+	s.invoices[invoice.ID] = invoice
+	s.lock.Unlock()
+
+	writeJSON(w, invoice)
+}
+
+func (s *Server) getInvoice(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := s.Invoice(r.PathValue("invoice"))
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, invoice)
+}
+
+func (s *Server) getPaymentMethods(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.Invoice(r.PathValue("invoice")); !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, []btcpay.InvoicePaymentMethod{})
+}
+
+func (s *Server) getServerInfo(w http.ResponseWriter, r *http.Request) {
+	var status = btcpay.ServerStatus{
+		Version:      "test",
+		FullySynched: true,
+	}
+	btc := btcpay.SyncStatus{
+		ChainHeight:     1,
+		SyncHeight:      1,
+		PaymentMethodID: "BTC-CHAIN",
+		Available:       true,
+	}
+	xmr := btcpay.SyncStatus{
+		PaymentMethodID: "XMR-CHAIN",
+		Available:       true,
+	}
+	xmr.Summary.Synced = true
+	xmr.Summary.DaemonAvailable = true
+	xmr.Summary.WalletAvailable = true
+	status.SyncStatuses = []btcpay.SyncStatus{btc, xmr}
+	writeJSON(w, status)
+}
+
+var checkoutTmpl = template.Must(template.New("").Parse(`<!DOCTYPE html>
+<html>
+	<head><meta charset="utf-8"><title>Test invoice {{.ID}}</title></head>
+	<body>
+		<h1>Test invoice {{.ID}}</h1>
+		<p>{{.Amount}} {{.Currency}}, order {{.OrderID}}, status {{.Status}}</p>
+		<form method="post">
+			<button name="status" value="processing">Processing</button>
+			<button name="status" value="settled">Settled</button>
+			<button name="status" value="expired">Expired</button>
+		</form>
+		{{with .RedirectURL}}<p><a href="{{.}}">Back to the shop</a></p>{{end}}
+	</body>
+</html>`))
+
+// checkoutGet replaces the BTCPay checkout page. It allows to drive the invoice manually.
+func (s *Server) checkoutGet(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := s.Invoice(r.PathValue("invoice"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	checkoutTmpl.Execute(w, invoice)
+}
+
+func (s *Server) checkoutPost(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("invoice")
+	var err error
+	switch r.PostFormValue("status") {
+	case "processing":
+		err = s.SetProcessing(id)
+	case "settled":
+		err = s.SetSettled(id)
+	case "expired":
+		err = s.SetExpired(id)
+	default:
+		err = errors.New("unknown status")
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomHex() string {
+	var b = make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -29,6 +29,9 @@ func init() {
 	dict := map[string]catalog.Dictionary{
 		"de_DE": &dictionary{index: de_DEIndex, data: de_DEData},
 		"en_US": &dictionary{index: en_USIndex, data: en_USData},
+		"es_ES": &dictionary{index: es_ESIndex, data: es_ESData},
+		"fr_FR": &dictionary{index: fr_FRIndex, data: fr_FRData},
+		"it_IT": &dictionary{index: it_ITIndex, data: it_ITData},
 	}
 	fallback := language.MustParse("en-US")
 	cat, err := catalog.NewFromMap(dict, catalog.Fallback(fallback))
@@ -39,211 +42,267 @@ func init() {
 }
 
 var messageKeyToIndex = map[string]int{
-	"%.2f":     143,
-	"%.2f EUR": 152,
+	"%.2f":     145,
+	"%.2f EUR": 154,
 	"A payment is on the way, but we're still waiting for the required amount of confirmations on the blockchain.": 1,
-	"Account holder":            148,
-	"Address":                   106,
-	"All Services and Projects": 121,
-	"Amount":                    141,
-	"As soon as your payment arrives, your voucher codes are shown. In the unlikely case that your goods have become sold out in the meantime, your codes will appear as soon as they are back in stock.": 99,
-	"Australian dollars":                50,
-	"Austria":                           15,
-	"BIC (if required)":                 150,
-	"Bank Transfer to our SEPA Account": 49,
-	"Bank name (if required)":           151,
-	"Belgium":                           16,
-	"Bookmark this page or save its address in another way. You will need it to access your goods.": 96,
-	"Bookmark your order. You will need it to access your goods.":                                   73,
-	"Bulgaria":      17,
-	"Bulgarian lev": 51,
-	"Buy":           89,
-	"Buy coupons, voucher codes and gift cards for privacy services and pay anonymously with Monero, Bitcoin or cash letter. SEPA Bank transfer is also available.": 69,
-	"Canadian dollars":              52,
-	"Cancellation Policy":           117,
-	"Cash":                          48,
-	"Cash by mail in 18 currencies": 118,
-	"Cash in Foreign Currency":      47,
-	"Cash: Send cash (we accept 20 currencies) to our office in Germany. We shred the letter after processing.": 77,
-	"Chinese renminbi": 54,
-	"Click to copy":    97,
-	"Contact & News":   128,
-	"Contact us":       129,
-	"Country options are limited by your IP address and browser language.": 88,
-	"Croatia":               29,
-	"Currency":              142,
-	"Current deletion date": 93,
-	"Cyprus":                19,
-	"Czech koruna":          55,
-	"Czechia":               20,
-	"Danish krone":          56,
-	"Denmark":               22,
-	"Digital Goods":         124,
-	"Email":                 105,
-	"Enter the quantity and press „Buy“.":                             72,
+	"Account holder":            150,
+	"Address":                   108,
+	"Address formats":           197,
+	"All Services and Projects": 123,
+	"All items delivered":       192,
+	"Amount":                    143,
+	"As soon as your payment arrives, your voucher codes are shown. In the unlikely case that your goods have become sold out in the meantime, your codes will appear as soon as they are back in stock.": 101,
+	"Australian dollars":                52,
+	"Austria":                           17,
+	"BIC (if required)":                 152,
+	"Bank Transfer to our SEPA Account": 51,
+	"Bank name (if required)":           153,
+	"Belgium":                           18,
+	"Bookmark this page or save its address in another way. You will need it to access your goods.": 98,
+	"Bookmark your order. You will need it to access your goods.":                                   75,
+	"Bulgaria":      19,
+	"Bulgarian lev": 53,
+	"Buy":           91,
+	"Buy coupons, voucher codes and gift cards for privacy services and pay anonymously with Monero, Bitcoin or cash letter. SEPA Bank transfer is also available.": 71,
+	"Canadian dollars":              54,
+	"Cancellation Policy":           119,
+	"Cash":                          50,
+	"Cash by mail in 18 currencies": 120,
+	"Cash in Foreign Currency":      49,
+	"Cash: Send cash (we accept 20 currencies) to our office in Germany. We shred the letter after processing.": 79,
+	"Chinese renminbi": 56,
+	"Click to copy":    99,
+	"Code":             179,
+	"Contact & News":   130,
+	"Contact us":       131,
+	"Country options are limited by your IP address and browser language.": 90,
+	"Croatia":                             31,
+	"Currency":                            144,
+	"Current deletion date":               95,
+	"Cyprus":                              21,
+	"Czech koruna":                        57,
+	"Czechia":                             22,
+	"Danish krone":                        58,
+	"Delivery date":                       180,
+	"Denmark":                             24,
+	"Digital Goods":                       126,
+	"Discount code (optional)":            162,
+	"Download your codes":                 176,
+	"Email":                               107,
+	"Email with codes, OpenPGP encrypted": 183,
+	"Enter the quantity and press „Buy“.":                             74,
 	"Error displaying website. Please try again later.":               9,
 	"Error getting stock from database. Please try again later.":      8,
 	"Error inserting purchase into database. Please try again later.": 11,
-	"Error saving contact information. Please try again later.":       14,
-	"Estonia":        23,
-	"European Union": 85,
+	"Error saving contact information. Please try again later.":       16,
+	"Estonia":        25,
+	"Euro only":      158,
+	"European Union": 87,
 	"Finalized":      7,
-	"Finland":        25,
-	"France":         26,
-	"Germany":        21,
-	"Get notified when your payment arrives and your voucher codes are shown. The notification will not contain the order number or the link. Your contact information will be deleted afterwards.": 102,
-	"Got an idea or found an error? Drop us a note!": 134,
-	"Greece":          28,
-	"Hungary":         30,
-	"IBAN":            149,
-	"Icelandic króna": 58,
-	"If you are sending coins, please stick them down firmly. Otherwise they will be pressed out during transport.":                                                                                   144,
-	"If you use TOR or a VPN: The payment options displayed depend on the country of your IP address. In addition, PayPal blocks some TOR exit nodes. In that case, try „New Circuit for this Site“.": 146,
-	"Ireland":      31,
-	"Italy":        32,
-	"Japanese yen": 59,
-	"JavaScript is disabled in your browser. In order to receive updates on your order, please reload this page from time to time.": 94,
-	"Latvia":       35,
-	"Legal":        113,
-	"Legal Notice": 116,
-	"Lithuania":    33,
-	"Local Store":  123,
-	"Luxembourg":   34,
-	"Make a bank transfer to our German SEPA (Single Euro Payments Area) bank account. We check for new incoming payments manually every day. We will see your name and account number on our bank statement. If your bank account is outside the Single Euro Payments Area, please pay any fees yourself by selecting the „OUR“ fee option.": 147,
-	"Malta":              38,
-	"Message from store": 92,
-	"Mon+Thu 2pm-6pm":    131,
-	"Monero (XMR) or Bitcoin (BTC): Your voucher codes are shown as soon as your payment is confirmed on the blockchain.": 76,
-	"Monero and Bitcoin":        119,
-	"Monero or Bitcoin":         46,
-	"Montenegro":                36,
-	"Netherlands":               39,
-	"New":                       4,
-	"New Israeli shekel (NIS)":  60,
-	"New Taiwan dollars":        67,
-	"New Zealand dollars":       62,
-	"North Macedonia":           37,
-	"Norwegian krone":           61,
-	"Not in the European Union": 84,
-	"Online printing":           127,
-	"Online shop":               125,
-	"Opening hours":             130,
-	"Optional: Get notified by email or ntfy.sh when your payment arrives.": 79,
-	"Optional: Get notified when your payment arrives":                      101,
-	"Or scan the EPC QR code:":                                              154,
-	"Order":                                                                 90,
-	"Order Service":                                                         126,
-	"Order with a few clicks. Pay with Monero, Bitcoin, cash in 20 currencies, or SEPA Bank transfer.": 70,
+	"Finland":        27,
+	"France":         28,
+	"Germany":        23,
+	"Get notified when your payment arrives and your voucher codes are shown. The notification will not contain the order number or the link. Your contact information will be deleted afterwards.": 104,
+	"Got an idea or found an error? Drop us a note!": 136,
+	"Greece":          30,
+	"Hungary":         32,
+	"IBAN":            151,
+	"Icelandic króna": 60,
+	"If you are sending coins, please stick them down firmly. Otherwise they will be pressed out during transport.":                                                                                   146,
+	"If you use TOR or a VPN: The payment options displayed depend on the country of your IP address. In addition, PayPal blocks some TOR exit nodes. In that case, try „New Circuit for this Site“.": 148,
+	"Ireland":      33,
+	"Italy":        34,
+	"Japanese yen": 61,
+	"JavaScript is disabled in your browser. In order to receive updates on your order, please reload this page from time to time.": 96,
+	"Latvia":       37,
+	"Legal":        115,
+	"Legal Notice": 118,
+	"Lithuania":    35,
+	"Local Store":  125,
+	"Luxembourg":   36,
+	"Make a bank transfer to our German SEPA (Single Euro Payments Area) bank account. We check for new incoming payments manually every day. We will see your name and account number on our bank statement. If your bank account is outside the Single Euro Payments Area, please pay any fees yourself by selecting the „OUR“ fee option.": 149,
+	"Malta":                              40,
+	"Maximum number of items per order:": 170,
+	"Maximum quantity per order:":        171,
+	"Message from store":                 94,
+	"Mon+Thu 2pm-6pm":                    133,
+	"Monero (XMR) or Bitcoin (BTC): Your voucher codes are shown as soon as your payment is confirmed on the blockchain.": 78,
+	"Monero and Bitcoin":               121,
+	"Monero or Bitcoin":                48,
+	"Montenegro":                       38,
+	"My orders":                        173,
+	"Netherlands":                      41,
+	"New":                              4,
+	"New Israeli shekel (NIS)":         62,
+	"New Taiwan dollars":               69,
+	"New Zealand dollars":              64,
+	"New message regarding your order": 195,
+	"North Macedonia":                  39,
+	"Norwegian krone":                  63,
+	"Not enough in stock. Available:":  172,
+	"Not in the European Union":        86,
+	"Online printing":                  129,
+	"Online shop":                      127,
+	"Opening hours":                    132,
+	"Optional: Get notified by email or ntfy.sh when your payment arrives.": 81,
+	"Optional: Get notified when your payment arrives":                      103,
+	"Optional: Receive your codes by encrypted email":                       184,
+	"Or scan the EPC QR code:":                                              156,
+	"Order":                                                                 92,
+	"Order Service":                                                         128,
+	"Order with a few clicks. Pay with Monero, Bitcoin, cash in 20 currencies, or SEPA Bank transfer.": 72,
 	"Our service thinks that you are a bot. If you are not, please contact us.":                        10,
-	"Overall Sum": 111,
-	"Pay the specified amount in one of the following currencies.": 139,
-	"Pay using Monero or Bitcoin":                                  136,
-	"Pay with Monero (XMR) or Bitcoin (BTC). The full amount must be paid with a single transaction to the given address within 60 minutes. If your payment arrives too late, we have to confirm it manually. If in doubt, please contact us.": 135,
-	"Pay your order.": 74,
-	"Pay your order. Unpaid orders are deleted after 30 days.": 98,
-	"Payment":            100,
+	"Overall Sum": 113,
+	"Paste your OpenPGP public key and enter your email address above. When your codes have been delivered, we send them to you in an encrypted email. Your key and address are deleted afterwards.": 185,
+	"Pay the specified amount in one of the following currencies.": 141,
+	"Pay using Monero or Bitcoin":                                  138,
+	"Pay with Monero (XMR) or Bitcoin (BTC). The full amount must be paid with a single transaction to the given address within 60 minutes. If your payment arrives too late, we have to confirm it manually. If in doubt, please contact us.": 137,
+	"Pay your order.": 76,
+	"Pay your order. Unpaid orders are deleted after 30 days.": 100,
+	"Payment":            102,
 	"Payment processing": 5,
-	"Please include a note with this order number":                                            138,
-	"Please select some products.":                                                            81,
-	"Please select your country of residence.":                                                87,
-	"Please send undamaged banknotes only and round up if necessary. We do not accept coins.": 140,
-	"Poland":         40,
-	"Polish złoty":   63,
-	"Portugal":       41,
-	"Pound sterling": 57,
-	"Privacy policy": 115,
-	"Purpose":        153,
-	"Read more":      71,
-	"Romania":        42,
-	"Romanian leu":   64,
-	"SEPA (Single Euro Payments Area) bank transfer to our German bank account. We manually check for new payments every day.": 78,
-	"SEPA bank transfer":              120,
-	"Save":                            107,
-	"See here for short-term changes": 133,
-	"Select":                          104,
-	"Select notification method":      103,
-	"Send cash in an insured letter or package to our store address in Germany. After we take out the money, we shred the letter. Please check the cash shipment limits of your postal company (e. g. Deutsche Post „Einschreiben Wert“ up to 100 Euros within Germany, DHL Parcel up to 500 Euros). Send it to:": 137,
-	"Serbian dinar":                  65,
-	"Show prices and delivery dates": 112,
-	"Slovakia":                       45,
-	"Slovenia":                       44,
-	"Spain":                          24,
-	"Status":                         91,
-	"Sweden":                         43,
-	"Swedish krona":                  66,
-	"Swiss francs":                   53,
-	"Switzerland":                    18,
-	"Terms and Conditions":           114,
-	"There is no such purchase, or it has been deleted, or the URL is incorrect.": 12,
-	"There is no such purchase, or it has been deleted.":                          13,
-	"Tue+Wed+Fri+Sat 10am-2pm":                                                    132,
-	"Underdelivered":                                                              6,
-	"United Kingdom":                                                              27,
-	"United States dollars":                                                       68,
-	"Unpaid orders are deleted after 30 days.":                                    75,
-	"We are waiting for your payment.":                                            0,
-	"We have received your payment, but have gone out of stock meanwhile. You will receive the missing codes here as soon as possible. Sorry for the inconvenience.": 2,
-	"We only send the order number to PayPal. Your ordered items and delivery or pickup details will not be sent to PayPal.":                                         145,
-	"What's next?": 95,
-	"Where do you live? (We have to ask that for tax reasons. It does not affect the price or the goods.)": 83,
-	"Why?": 122,
-	"Write down your codes. We will delete them 30 days after delivery.":                                       80,
-	"You will receive the missing codes here as soon as they are in stock again. Sorry for the inconvenience.": 110,
-	"Your Order":                      109,
-	"Your Voucher Codes":              108,
+	"Payment received":   187,
+	"Payment received, some items are pending":                                                190,
+	"Please check the quantities of the highlighted products.":                                169,
+	"Please include a note with this order number":                                            140,
+	"Please paste exactly one OpenPGP public key.":                                            14,
+	"Please select some products.":                                                            83,
+	"Please select your country of residence.":                                                89,
+	"Please send undamaged banknotes only and round up if necessary. We do not accept coins.": 142,
+	"Poland":              42,
+	"Polish złoty":        65,
+	"Portugal":            43,
+	"Pound sterling":      59,
+	"Price per item from": 168,
+	"Prices in other currencies are indicative and based on the current exchange rate. You pay in euro, or in cash at the exchange rate of the day of your order.": 161,
+	"Privacy policy": 117,
+	"Product":        178,
+	"Purpose":        155,
+	"QR code":        182,
+	"Read more":      73,
+	"Romania":        44,
+	"Romanian leu":   66,
+	"SEPA (Single Euro Payments Area) bank transfer to our German bank account. We manually check for new payments every day.": 80,
+	"SEPA bank transfer":              122,
+	"Save":                            109,
+	"See here for short-term changes": 135,
+	"Select":                          106,
+	"Select notification method":      105,
+	"Send cash in an insured letter or package to our store address in Germany. After we take out the money, we shred the letter. Please check the cash shipment limits of your postal company (e. g. Deutsche Post „Einschreiben Wert“ up to 100 Euros within Germany, DHL Parcel up to 500 Euros). Send it to:": 139,
+	"Serbian dinar":                  67,
+	"Show":                           160,
+	"Show QR code":                   181,
+	"Show prices also in":            157,
+	"Show prices and delivery dates": 114,
+	"Slovakia":                       47,
+	"Slovenia":                       46,
+	"Spain":                          26,
+	"Status":                         93,
+	"Sweden":                         45,
+	"Swedish krona":                  68,
+	"Swiss francs":                   55,
+	"Switzerland":                    20,
+	"Terms and Conditions":           116,
+	"Text":                           177,
+	"The remaining items of your order have been delivered. Please download your vouchers within the next 30 days.": 194,
+	"The remaining items of your order have been delivered. Your codes are listed below.":                           193,
+	"There are no orders in this browser session.":                                                                  175,
+	"There is no such purchase, or it has been deleted, or the URL is incorrect.":                                   12,
+	"There is no such purchase, or it has been deleted.":                                                            13,
+	"This OpenPGP public key can't be used for encryption.":                                                         15,
+	"This discount code can't be used for this order.":                                                              166,
+	"This discount code does not apply to the selected products.":                                                   165,
+	"This discount code has been used up.":                                                                          167,
+	"This discount code has expired or is not valid yet.":                                                           164,
+	"This discount code is not valid.":                                                                              163,
+	"Tue+Wed+Fri+Sat 10am-2pm":                                                                                      134,
+	"Underdelivered":                                                                                                6,
+	"United Kingdom":                                                                                                29,
+	"United States dollars":                                                                                         70,
+	"Unpaid orders are deleted after 30 days.":                                                                      77,
+	"We are waiting for your payment.":                                                                              0,
+	"We have left a message for you on your order page. Please have a look at it.":                                  196,
+	"We have received your payment, but have gone out of stock meanwhile. You will receive the missing codes here as soon as possible. Sorry for the inconvenience.":                                                            2,
+	"We have received your payment. Please download your vouchers within the next 30 days.":                                                                                                                                     189,
+	"We have received your payment. Unfortunately some of the items you ordered are out of stock. We will deliver them as soon as possible and notify you again. You can already download the items which have been delivered.": 191,
+	"We have received your payment. Your codes are listed below.":                                                                                                                                                               188,
+	"We only send the order number to PayPal. Your ordered items and delivery or pickup details will not be sent to PayPal.":                                                                                                    147,
+	"What's next?": 97,
+	"Where do you live? (We have to ask that for tax reasons. It does not affect the price or the goods.)": 85,
+	"Why?": 124,
+	"Write down your codes. We will delete them 30 days after delivery.":                                       82,
+	"You will receive the missing codes here as soon as they are in stock again. Sorry for the inconvenience.": 112,
+	"Your Order":                      111,
+	"Your Voucher Codes":              110,
 	"Your codes have been delivered.": 3,
-	"in stock":                        82,
-	"please select":                   86,
+	"Your payment has been registered and is being processed. We will notify you again when it has been confirmed.":                   186,
+	"Your recent orders are stored in a cookie in this browser only. Bookmark your orders if you want to access them from elsewhere.": 174,
+	"also in":       159,
+	"in stock":      84,
+	"please select": 88,
 }
 
-var de_DEIndex = []uint32{ // 156 elements
+var de_DEIndex = []uint32{ // 199 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000002b, 0x000000a1, 0x00000155,
 	0x00000176, 0x0000017a, 0x00000193, 0x000001a2,
 	0x000001b0, 0x0000020b, 0x00000252, 0x000002ab,
-	0x00000309, 0x00000368, 0x000003a7, 0x000003fe,
-	0x0000040a, 0x00000412, 0x0000041c, 0x00000424,
-	0x0000042b, 0x00000436, 0x00000442, 0x0000044c,
-	0x00000454, 0x0000045c, 0x00000465, 0x00000470,
-	0x00000488, 0x00000495, 0x0000049e, 0x000004a5,
+	0x00000309, 0x00000368, 0x000003a7, 0x000003e5,
+	0x0000043e, 0x00000495, 0x000004a1, 0x000004a9,
+	0x000004b3, 0x000004bb, 0x000004c2, 0x000004cd,
+	0x000004d9, 0x000004e3, 0x000004eb, 0x000004f3,
+	0x000004fc, 0x00000507, 0x0000051f, 0x0000052c,
 	// Entry 20 - 3F
-	0x000004ac, 0x000004b4, 0x000004bc, 0x000004c6,
-	0x000004cf, 0x000004da, 0x000004e9, 0x000004ef,
-	0x000004fb, 0x00000501, 0x0000050a, 0x00000514,
-	0x0000051d, 0x00000527, 0x00000530, 0x00000544,
-	0x0000055d, 0x00000565, 0x0000058b, 0x0000059f,
-	0x000005af, 0x000005c1, 0x000005d3, 0x000005e8,
-	0x000005fc, 0x0000060d, 0x0000061d, 0x00000631,
-	0x00000640, 0x0000065f, 0x00000672, 0x00000684,
+	0x00000535, 0x0000053c, 0x00000543, 0x0000054b,
+	0x00000553, 0x0000055d, 0x00000566, 0x00000571,
+	0x00000580, 0x00000586, 0x00000592, 0x00000598,
+	0x000005a1, 0x000005ab, 0x000005b4, 0x000005be,
+	0x000005c7, 0x000005db, 0x000005f4, 0x000005fc,
+	0x00000622, 0x00000636, 0x00000646, 0x00000658,
+	0x0000066a, 0x0000067f, 0x00000693, 0x000006a4,
+	0x000006b4, 0x000006c8, 0x000006d7, 0x000006f6,
 	// Entry 40 - 5F
-	0x00000695, 0x000006a5, 0x000006b5, 0x000006c8,
-	0x000006d6, 0x000006e0, 0x00000789, 0x000007f9,
-	0x00000805, 0x00000840, 0x0000089b, 0x000008b5,
-	0x000008ed, 0x0000096c, 0x000009f7, 0x00000a64,
-	0x00000abf, 0x00000b0d, 0x00000b3b, 0x00000b45,
-	0x00000bd4, 0x00000bf7, 0x00000c0a, 0x00000c1b,
-	0x00000c48, 0x00000c94, 0x00000c9b, 0x00000ca6,
-	0x00000cad, 0x00000cc0, 0x00000cd8, 0x00000d4a,
+	0x00000709, 0x0000071b, 0x0000072c, 0x0000073c,
+	0x0000074c, 0x0000075f, 0x0000076d, 0x00000777,
+	0x00000820, 0x00000890, 0x0000089c, 0x000008d7,
+	0x00000932, 0x0000094c, 0x00000984, 0x00000a03,
+	0x00000a8e, 0x00000afb, 0x00000b56, 0x00000ba4,
+	0x00000bd2, 0x00000bdc, 0x00000c6b, 0x00000c8e,
+	0x00000ca1, 0x00000cb2, 0x00000cdf, 0x00000d2b,
+	0x00000d32, 0x00000d3d, 0x00000d44, 0x00000d57,
 	// Entry 60 - 7F
-	0x00000d5e, 0x00000dd8, 0x00000def, 0x00000e41,
-	0x00000f2c, 0x00000f34, 0x00000f76, 0x00001053,
-	0x00001077, 0x00001082, 0x00001089, 0x00001092,
-	0x0000109c, 0x000010b1, 0x000010c2, 0x0000112f,
-	0x0000113b, 0x0000115b, 0x00001167, 0x0000116b,
-	0x00001177, 0x00001181, 0x00001194, 0x000011b6,
-	0x000011c9, 0x000011df, 0x000011fa, 0x00001201,
-	0x00001210, 0x00001220, 0x0000122b, 0x0000123a,
+	0x00000d6f, 0x00000de1, 0x00000df5, 0x00000e6f,
+	0x00000e86, 0x00000ed8, 0x00000fc3, 0x00000fcb,
+	0x0000100d, 0x000010ea, 0x0000110e, 0x00001119,
+	0x00001120, 0x00001129, 0x00001133, 0x00001148,
+	0x00001159, 0x000011c6, 0x000011d2, 0x000011f2,
+	0x000011fe, 0x00001202, 0x0000120e, 0x00001218,
+	0x0000122b, 0x0000124d, 0x00001260, 0x00001276,
+	0x00001291, 0x00001298, 0x000012a7, 0x000012b7,
 	// Entry 80 - 9F
-	0x0000124a, 0x00001259, 0x00001261, 0x00001271,
-	0x00001281, 0x00001297, 0x000012bf, 0x000012e2,
-	0x0000140f, 0x00001435, 0x00001578, 0x000015aa,
-	0x000015ea, 0x0000164e, 0x00001655, 0x0000165e,
-	0x00001663, 0x000016c8, 0x00001761, 0x0000185b,
-	0x000019ce, 0x000019db, 0x000019e0, 0x000019f3,
-	0x00001a07, 0x00001a10, 0x00001a23, 0x00001a40,
-} // Size: 648 bytes
+	0x000012c2, 0x000012d1, 0x000012e1, 0x000012f0,
+	0x000012f8, 0x00001308, 0x00001318, 0x0000132e,
+	0x00001356, 0x00001379, 0x000014a6, 0x000014cc,
+	0x0000160f, 0x00001641, 0x00001681, 0x000016e5,
+	0x000016ec, 0x000016f5, 0x000016fa, 0x0000175f,
+	0x000017f8, 0x000018f2, 0x00001a65, 0x00001a72,
+	0x00001a77, 0x00001a8a, 0x00001a9e, 0x00001aa7,
+	0x00001aba, 0x00001ad7, 0x00001aef, 0x00001af8,
+	// Entry A0 - BF
+	0x00001b00, 0x00001b09, 0x00001bae, 0x00001bc4,
+	0x00001be5, 0x00001c1f, 0x00001c5d, 0x00001ca2,
+	0x00001cce, 0x00001cdd, 0x00001d13, 0x00001d3b,
+	0x00001d5b, 0x00001d7e, 0x00001d91, 0x00001e45,
+	0x00001e7b, 0x00001e95, 0x00001e9a, 0x00001ea2,
+	0x00001ea7, 0x00001eb3, 0x00001ec4, 0x00001ecc,
+	0x00001ef5, 0x00001f2f, 0x0000201f, 0x00002093,
+	0x000020a4, 0x000020e4, 0x0000214c, 0x0000217d,
+	// Entry C0 - DF
+	0x00002264, 0x0000227b, 0x000022d4, 0x00002355,
+	0x00002379, 0x000023d3, 0x000023e1,
+} // Size: 820 bytes
 
-const de_DEData string = "" + // Size: 6720 bytes
+const de_DEData string = "" + // Size: 9185 bytes
 	"\x02Wir warten auf den Eingang deiner Zahlung.\x02Eine Zahlung wurde ang" +
 	"ekündigt, aber wir warten noch auf die erforderliche Anzahl Bestätigunge" +
 	"n auf der Blockchain.\x02Wir haben deine Zahlung erhalten, aber unser Vo" +
@@ -257,141 +316,190 @@ const de_DEData string = "" + // Size: 6720 bytes
 	"beim Einfügen der Bestellung in die Datenbank. Bitte versuche es später " +
 	"noch einmal.\x02Diese Bestellung existiert nicht oder wurde bereits gelö" +
 	"scht, oder die Webadresse ist falsch.\x02Diese Bestellung existiert nich" +
-	"t oder wurde bereits gelöscht.\x02Fehler beim Speichern der Kontaktinfor" +
-	"mationen. Bitte versuche es später noch einmal.\x02Österreich\x02Belgien" +
-	"\x02Bulgarien\x02Schweiz\x02Zypern\x02Tschechien\x02Deutschland\x02Dänem" +
-	"ark\x02Estland\x02Spanien\x02Finnland\x02Frankreich\x02Vereinigtes König" +
-	"reich\x02Griechenland\x02Kroatien\x02Ungarn\x02Irland\x02Italien\x02Lita" +
-	"uen\x02Luxemburg\x02Lettland\x02Montenegro\x02Nordmazedonien\x02Malta" +
-	"\x02Niederlande\x02Polen\x02Portugal\x02Rumänien\x02Schweden\x02Slowenie" +
-	"n\x02Slowakei\x02Monero oder Bitcoin\x02Bargeld in Fremdwährung\x02Barge" +
-	"ld\x02Banküberweisung auf unser SEPA-Konto\x02Australische Dollar\x02Bul" +
-	"garische Lew\x02Kanadische Dollar\x02Schweizer Franken\x02Chinesische Re" +
-	"nminbi\x02Tschechische Kronen\x02Dänische Kronen\x02Britische Pfund\x02I" +
-	"sländische Kronen\x02Japanische Yen\x02Neue israelische Schekel (NIS)" +
-	"\x02Norwegische Kronen\x02Neuseeland-Dollar\x02Polnische Złoty\x02Rumäni" +
-	"sche Leu\x02Serbische Dinar\x02Schwedische Kronen\x02Taiwan-Dollar\x02US" +
-	"-Dollar\x02Hier kannst du Gutscheine für Privatsphäre-freundliche Dienst" +
-	"e kaufen und anonym mit Monero, Bitcoin oder Bargeld bezahlen. SEPA-Bank" +
-	"überweisung ist auch verfügbar.\x02Bestelle mit wenigen Klicks. Bezahle" +
-	" mit Monero, Bitcoin, Bargeld in 20 Währungen, oder SEPA-Banküberweisung" +
-	".\x02Weiterlesen\x02Wähle die gewünschte Anzahl aus und klicke „Kaufen“." +
-	"\x02Speichere deine Bestellung als Lesezeichen. Du brauchst sie, um auf " +
-	"die Codes zuzugreifen.\x02Bezahle deine Bestellung.\x02Unbezahlte Bestel" +
-	"lungen werden nach 30 Tagen gelöscht.\x02Monero (XMR) oder Bitcoin (BTC)" +
-	": Deine Gutscheincodes werden angezeigt, sobald deine Zahlung in der Blo" +
-	"ckchain bestätigt ist.\x02Bargeld: Schicke Bargeld (wir akzeptieren 20 W" +
-	"ährungen) an unsere Adresse in Deutschland. Wir schreddern den Brief na" +
-	"ch dem Freischalten.\x02SEPA-Überweisung auf unser deutsches Bankkonto. " +
-	"Wir prüfen es täglich manuell auf neue Zahlungseingänge.\x02Optional: La" +
-	"ss dich per E-Mail oder ntfy.sh benachrichtigen, wenn deine Zahlung eint" +
-	"rifft.\x02Notiere dir die Codes. Wir werden sie 30 Tage nach der Auslief" +
-	"erung löschen.\x02Bitte wähle eines oder mehrere Produkte aus.\x02vorrät" +
-	"ig\x02In welchem Land bist du ansässig? (Das müssen wir aus steuerlichen" +
-	" Gründen fragen. Es hat keinen Einfluss auf den Preis oder die Leistung." +
-	")\x02Außerhalb der Europäischen Union\x02Europäische Union\x02bitte ausw" +
-	"ählen\x02Bitte wähle das Land aus, in dem du wohnst.\x02Die Länderoptio" +
-	"nen hängen von deiner IP-Adresse und Spracheinstellung ab.\x02Kaufen\x02" +
-	"Bestellung\x02Status\x02Nachricht vom Shop\x02Derzeitiges Löschdatum\x02" +
-	"Du hast JavaScript deaktiviert. Um über Neuigkeiten informiert zu werden" +
-	", lade die Seite bitte gelegentlich neu.\x02Wie geht es weiter?\x02Setze" +
-	" diese Seite als Lesezeichen oder speichere ihre Adresse anderweitig. Du" +
-	" brauchst sie, um auf die Codes zuzugreifen.\x02Anklicken zum Kopieren" +
-	"\x02Bezahle deine Bestellung. Unbezahlte Bestellungen werden nach 30 Tag" +
-	"en gelöscht.\x02Sobald deine Zahlung bei uns eintrifft, werden dir deine" +
-	" Gutscheincodes angezeigt. In seltenen Fällen kann es passieren, dass da" +
-	"s Produkt zwischenzeitlich ausverkauft ist. Dann werden dir die Codes an" +
-	"gezeigt, sobald Nachschub da ist.\x02Zahlung\x02Optional: Lass dich bena" +
-	"chrichtigen, wenn deine Zahlung eintrifft\x02Lass dich benachrichtigen, " +
-	"wenn deine Zahlung eingegangen ist und die Voucher-Codes angezeigt werde" +
-	"n. Die Benachrichtigung wird weder die Bestellnummer noch den Link entha" +
-	"lten. Deine Kontaktdaten werden danach gelöscht.\x02Benachrichtigungsmet" +
-	"hode auswählen\x02Wähle aus\x02E-Mail\x02Addresse\x02Speichern\x02Deine " +
-	"Gutscheincodes\x02Deine Bestellung\x02Die fehlenden Codes erhälst du, so" +
-	"bald Nachschub eintroffen ist. Wir bitten die Umstände zu entschuldigen." +
-	"\x02Gesamtsumme\x02Preise und Lieferdatum anzeigen\x02Rechtliches\x02AGB" +
-	"\x02Datenschutz\x02Impressum\x02Widerrufsbelehrung\x02Bargeld per Post i" +
-	"n 18 Währungen\x02Monero und Bitcoin\x02SEPA-Banküberweisung\x02Alle Ang" +
-	"ebote und Projekte\x02Warum?\x02Ladengeschäft\x02Digitale Güter\x02Onlin" +
-	"eshop\x02Bestellservice\x02Onlinedruckerei\x02Kontakt & News\x02Kontakt" +
-	"\x02Öffnungszeiten\x02Mo+Do 14-18 Uhr\x02Di+Mi+Fr+Sa 10-14 Uhr\x02Sieh h" +
-	"ier für kurzfristige Änderungen\x02Fehler oder Hinweise? Schreib uns!" +
-	"\x02Bezahle den angegebenen Betrag in Monero (XMR) oder Bitcoin (BTC). D" +
-	"er Betrag muss innerhalb von 60 Minuten vollständig und als einzelne Tra" +
-	"nsaktion auf der angegebenen Adresse eingehen. Falls deine Zahlung versp" +
-	"ätet eintrifft, müssen wir sie manuell bestätigen. Im Zweifel kontaktie" +
-	"re uns bitte.\x02Zur Bezahlung mit Monero oder Bitcoin\x02Sende uns Barg" +
-	"eld in einem versichertem Brief oder Paket. Nachdem wir das Geld entnomm" +
-	"en haben, schreddern wir den Brief. Bitte beachte die Höchstgrenzen dein" +
-	"es Postunternehmens für den Bargeldversand (z. B. Deutsche Post „Einschr" +
-	"eiben Wert“ bis 100 Euro innerhalb Deutschlands, DHL Paket bis 500 Euro)" +
-	". Sende es an:\x02Bitte lege einen Zettel mit der Bestellnummer bei\x02Z" +
-	"ahle den angegebenen Betrag in einer der folgenden Währungen.\x02Bitte s" +
-	"ende nur unbeschädigte Banknoten und runde gegebenenfalls auf. Wir nehme" +
-	"n keine Münzen an.\x02Betrag\x02Währung\x02%.2f\x02Falls du Münzen sende" +
-	"st, klebe sie bitte gut fest. Sonst werden sie beim Transport herausgedr" +
-	"ückt.\x02Wir übermitteln nur die Bestellnummer an PayPal. Deine bestell" +
-	"ten Artikel sowie die Details zu Lieferung oder Abholung werden nicht an" +
-	" PayPal gesendet.\x02Falls du TOR oder einen VPN benutzt: Die angezeigte" +
-	"n Bezahlmöglichkeiten sind von der Länderzuordnung deiner IP-Adresse abh" +
-	"ängig. Darüber hinaus blockiert PayPal manche TOR Exit Nodes. In dem Fa" +
-	"ll versuche es mit „New Circuit for this Site“.\x02Überweise das Geld au" +
-	"f unser deutsches SEPA-Bankkonto. Wir prüfen es täglich manuell auf neue" +
-	" Zahlungseingänge. Wir werden deinen Namen und deine Kontonummer auf uns" +
-	"erem Kontoauszug sehen. Falls dein Konto außerhalb des einheitlichen Eur" +
-	"o-Zahlungsverkehrsraums (SEPA) liegt, zahle eventuelle Gebühren bitte se" +
-	"lbst, indem du die Gebührenregelung „OUR“ wählst.\x02Kontoinhaber\x02IBA" +
-	"N\x02BIC (falls nötig)\x02Bank (falls nötig)\x02%.2f €\x02Überweisungszw" +
-	"eck\x02Oder scanne den EPC-QR-Code:"
+	"t oder wurde bereits gelöscht.\x02Bitte füge genau einen öffentlichen Op" +
+	"enPGP-Schlüssel ein.\x02Dieser öffentliche OpenPGP-Schlüssel kann nicht " +
+	"zur Verschlüsselung verwendet werden.\x02Fehler beim Speichern der Konta" +
+	"ktinformationen. Bitte versuche es später noch einmal.\x02Österreich\x02" +
+	"Belgien\x02Bulgarien\x02Schweiz\x02Zypern\x02Tschechien\x02Deutschland" +
+	"\x02Dänemark\x02Estland\x02Spanien\x02Finnland\x02Frankreich\x02Vereinig" +
+	"tes Königreich\x02Griechenland\x02Kroatien\x02Ungarn\x02Irland\x02Italie" +
+	"n\x02Litauen\x02Luxemburg\x02Lettland\x02Montenegro\x02Nordmazedonien" +
+	"\x02Malta\x02Niederlande\x02Polen\x02Portugal\x02Rumänien\x02Schweden" +
+	"\x02Slowenien\x02Slowakei\x02Monero oder Bitcoin\x02Bargeld in Fremdwähr" +
+	"ung\x02Bargeld\x02Banküberweisung auf unser SEPA-Konto\x02Australische D" +
+	"ollar\x02Bulgarische Lew\x02Kanadische Dollar\x02Schweizer Franken\x02Ch" +
+	"inesische Renminbi\x02Tschechische Kronen\x02Dänische Kronen\x02Britisch" +
+	"e Pfund\x02Isländische Kronen\x02Japanische Yen\x02Neue israelische Sche" +
+	"kel (NIS)\x02Norwegische Kronen\x02Neuseeland-Dollar\x02Polnische Złoty" +
+	"\x02Rumänische Leu\x02Serbische Dinar\x02Schwedische Kronen\x02Taiwan-Do" +
+	"llar\x02US-Dollar\x02Hier kannst du Gutscheine für Privatsphäre-freundli" +
+	"che Dienste kaufen und anonym mit Monero, Bitcoin oder Bargeld bezahlen." +
+	" SEPA-Banküberweisung ist auch verfügbar.\x02Bestelle mit wenigen Klicks" +
+	". Bezahle mit Monero, Bitcoin, Bargeld in 20 Währungen, oder SEPA-Banküb" +
+	"erweisung.\x02Weiterlesen\x02Wähle die gewünschte Anzahl aus und klicke " +
+	"„Kaufen“.\x02Speichere deine Bestellung als Lesezeichen. Du brauchst s" +
+	"ie, um auf die Codes zuzugreifen.\x02Bezahle deine Bestellung.\x02Unbeza" +
+	"hlte Bestellungen werden nach 30 Tagen gelöscht.\x02Monero (XMR) oder Bi" +
+	"tcoin (BTC): Deine Gutscheincodes werden angezeigt, sobald deine Zahlung" +
+	" in der Blockchain bestätigt ist.\x02Bargeld: Schicke Bargeld (wir akzep" +
+	"tieren 20 Währungen) an unsere Adresse in Deutschland. Wir schreddern de" +
+	"n Brief nach dem Freischalten.\x02SEPA-Überweisung auf unser deutsches B" +
+	"ankkonto. Wir prüfen es täglich manuell auf neue Zahlungseingänge.\x02Op" +
+	"tional: Lass dich per E-Mail oder ntfy.sh benachrichtigen, wenn deine Za" +
+	"hlung eintrifft.\x02Notiere dir die Codes. Wir werden sie 30 Tage nach d" +
+	"er Auslieferung löschen.\x02Bitte wähle eines oder mehrere Produkte aus." +
+	"\x02vorrätig\x02In welchem Land bist du ansässig? (Das müssen wir aus st" +
+	"euerlichen Gründen fragen. Es hat keinen Einfluss auf den Preis oder die" +
+	" Leistung.)\x02Außerhalb der Europäischen Union\x02Europäische Union\x02" +
+	"bitte auswählen\x02Bitte wähle das Land aus, in dem du wohnst.\x02Die Lä" +
+	"nderoptionen hängen von deiner IP-Adresse und Spracheinstellung ab.\x02K" +
+	"aufen\x02Bestellung\x02Status\x02Nachricht vom Shop\x02Derzeitiges Lösch" +
+	"datum\x02Du hast JavaScript deaktiviert. Um über Neuigkeiten informiert " +
+	"zu werden, lade die Seite bitte gelegentlich neu.\x02Wie geht es weiter?" +
+	"\x02Setze diese Seite als Lesezeichen oder speichere ihre Adresse anderw" +
+	"eitig. Du brauchst sie, um auf die Codes zuzugreifen.\x02Anklicken zum K" +
+	"opieren\x02Bezahle deine Bestellung. Unbezahlte Bestellungen werden nach" +
+	" 30 Tagen gelöscht.\x02Sobald deine Zahlung bei uns eintrifft, werden di" +
+	"r deine Gutscheincodes angezeigt. In seltenen Fällen kann es passieren, " +
+	"dass das Produkt zwischenzeitlich ausverkauft ist. Dann werden dir die C" +
+	"odes angezeigt, sobald Nachschub da ist.\x02Zahlung\x02Optional: Lass di" +
+	"ch benachrichtigen, wenn deine Zahlung eintrifft\x02Lass dich benachrich" +
+	"tigen, wenn deine Zahlung eingegangen ist und die Voucher-Codes angezeig" +
+	"t werden. Die Benachrichtigung wird weder die Bestellnummer noch den Lin" +
+	"k enthalten. Deine Kontaktdaten werden danach gelöscht.\x02Benachrichtig" +
+	"ungsmethode auswählen\x02Wähle aus\x02E-Mail\x02Addresse\x02Speichern" +
+	"\x02Deine Gutscheincodes\x02Deine Bestellung\x02Die fehlenden Codes erhä" +
+	"lst du, sobald Nachschub eintroffen ist. Wir bitten die Umstände zu ents" +
+	"chuldigen.\x02Gesamtsumme\x02Preise und Lieferdatum anzeigen\x02Rechtlic" +
+	"hes\x02AGB\x02Datenschutz\x02Impressum\x02Widerrufsbelehrung\x02Bargeld " +
+	"per Post in 18 Währungen\x02Monero und Bitcoin\x02SEPA-Banküberweisung" +
+	"\x02Alle Angebote und Projekte\x02Warum?\x02Ladengeschäft\x02Digitale Gü" +
+	"ter\x02Onlineshop\x02Bestellservice\x02Onlinedruckerei\x02Kontakt & News" +
+	"\x02Kontakt\x02Öffnungszeiten\x02Mo+Do 14-18 Uhr\x02Di+Mi+Fr+Sa 10-14 Uh" +
+	"r\x02Sieh hier für kurzfristige Änderungen\x02Fehler oder Hinweise? Schr" +
+	"eib uns!\x02Bezahle den angegebenen Betrag in Monero (XMR) oder Bitcoin " +
+	"(BTC). Der Betrag muss innerhalb von 60 Minuten vollständig und als einz" +
+	"elne Transaktion auf der angegebenen Adresse eingehen. Falls deine Zahlu" +
+	"ng verspätet eintrifft, müssen wir sie manuell bestätigen. Im Zweifel ko" +
+	"ntaktiere uns bitte.\x02Zur Bezahlung mit Monero oder Bitcoin\x02Sende u" +
+	"ns Bargeld in einem versichertem Brief oder Paket. Nachdem wir das Geld " +
+	"entnommen haben, schreddern wir den Brief. Bitte beachte die Höchstgrenz" +
+	"en deines Postunternehmens für den Bargeldversand (z. B. Deutsche Post „" +
+	"Einschreiben Wert“ bis 100 Euro innerhalb Deutschlands, DHL Paket bis 50" +
+	"0 Euro). Sende es an:\x02Bitte lege einen Zettel mit der Bestellnummer b" +
+	"ei\x02Zahle den angegebenen Betrag in einer der folgenden Währungen.\x02" +
+	"Bitte sende nur unbeschädigte Banknoten und runde gegebenenfalls auf. Wi" +
+	"r nehmen keine Münzen an.\x02Betrag\x02Währung\x02%.2f\x02Falls du Münze" +
+	"n sendest, klebe sie bitte gut fest. Sonst werden sie beim Transport her" +
+	"ausgedrückt.\x02Wir übermitteln nur die Bestellnummer an PayPal. Deine b" +
+	"estellten Artikel sowie die Details zu Lieferung oder Abholung werden ni" +
+	"cht an PayPal gesendet.\x02Falls du TOR oder einen VPN benutzt: Die ange" +
+	"zeigten Bezahlmöglichkeiten sind von der Länderzuordnung deiner IP-Adres" +
+	"se abhängig. Darüber hinaus blockiert PayPal manche TOR Exit Nodes. In d" +
+	"em Fall versuche es mit „New Circuit for this Site“.\x02Überweise das Ge" +
+	"ld auf unser deutsches SEPA-Bankkonto. Wir prüfen es täglich manuell auf" +
+	" neue Zahlungseingänge. Wir werden deinen Namen und deine Kontonummer au" +
+	"f unserem Kontoauszug sehen. Falls dein Konto außerhalb des einheitliche" +
+	"n Euro-Zahlungsverkehrsraums (SEPA) liegt, zahle eventuelle Gebühren bit" +
+	"te selbst, indem du die Gebührenregelung „OUR“ wählst.\x02Kontoinhaber" +
+	"\x02IBAN\x02BIC (falls nötig)\x02Bank (falls nötig)\x02%.2f €\x02Überwei" +
+	"sungszweck\x02Oder scanne den EPC-QR-Code:\x02Preise auch anzeigen in" +
+	"\x02Nur Euro\x02auch in\x02Anzeigen\x02Preise in anderen Währungen sind " +
+	"unverbindlich und beruhen auf dem aktuellen Wechselkurs. Du bezahlst in " +
+	"Euro oder in bar zum Wechselkurs am Tag deiner Bestellung.\x02Rabattcode" +
+	" (optional)\x02Dieser Rabattcode ist ungültig.\x02Dieser Rabattcode ist " +
+	"abgelaufen oder noch nicht gültig.\x02Dieser Rabattcode gilt nicht für d" +
+	"ie ausgewählten Produkte.\x02Dieser Rabattcode kann für diese Bestellung" +
+	" nicht verwendet werden.\x02Dieser Rabattcode ist bereits aufgebraucht." +
+	"\x02Stückpreis ab\x02Bitte überprüfe die Anzahl der markierten Produkte." +
+	"\x02Maximale Anzahl Artikel pro Bestellung:\x02Maximale Anzahl pro Beste" +
+	"llung:\x02Nicht genug vorrätig. Verfügbar:\x02Meine Bestellungen\x02Dein" +
+	"e letzten Bestellungen werden nur in einem Cookie in diesem Browser gesp" +
+	"eichert. Setze ein Lesezeichen auf deine Bestellungen, wenn du von woand" +
+	"ers darauf zugreifen möchtest.\x02In dieser Browser-Sitzung gibt es kein" +
+	"e Bestellungen.\x02Lade deine Codes herunter\x02Text\x02Produkt\x02Code" +
+	"\x02Lieferdatum\x02QR-Code anzeigen\x02QR-Code\x02E-Mail mit Codes, Open" +
+	"PGP-verschlüsselt\x02Optional: Erhalte deine Codes per verschlüsselter E" +
+	"-Mail\x02Füge deinen öffentlichen OpenPGP-Schlüssel ein und gib oben dei" +
+	"ne E-Mail-Adresse an. Sobald deine Codes ausgeliefert wurden, senden wir" +
+	" sie dir in einer verschlüsselten E-Mail. Dein Schlüssel und deine Adres" +
+	"se werden danach gelöscht.\x02Deine Zahlung wurde registriert und wird v" +
+	"erarbeitet. Wir benachrichtigen dich erneut, sobald sie bestätigt wurde." +
+	"\x02Zahlung erhalten\x02Wir haben deine Zahlung erhalten. Deine Codes fi" +
+	"ndest du unten.\x02Wir haben deine Zahlung erhalten. Bitte lade deine Gu" +
+	"tscheine innerhalb der nächsten 30 Tage herunter.\x02Zahlung erhalten, e" +
+	"inige Artikel stehen noch aus\x02Wir haben deine Zahlung erhalten. Leide" +
+	"r sind einige der bestellten Artikel nicht vorrätig. Wir liefern sie so " +
+	"bald wie möglich nach und benachrichtigen dich erneut. Die bereits gelie" +
+	"ferten Artikel kannst du schon herunterladen.\x02Alle Artikel geliefert" +
+	"\x02Die restlichen Artikel deiner Bestellung wurden geliefert. Deine Cod" +
+	"es findest du unten.\x02Die restlichen Artikel deiner Bestellung wurden " +
+	"geliefert. Bitte lade deine Gutscheine innerhalb der nächsten 30 Tage he" +
+	"runter.\x02Neue Nachricht zu deiner Bestellung\x02Wir haben dir eine Nac" +
+	"hricht auf deiner Bestellseite hinterlassen. Bitte sieh sie dir an.\x02A" +
+	"dressformate"
 
-var en_USIndex = []uint32{ // 156 elements
+var en_USIndex = []uint32{ // 199 elements
 	// Entry 0 - 1F
 	0x00000000, 0x00000021, 0x0000008e, 0x0000012d,
 	0x0000014d, 0x00000151, 0x00000164, 0x00000173,
 	0x0000017d, 0x000001b8, 0x000001ea, 0x00000234,
-	0x00000274, 0x000002c0, 0x000002f3, 0x0000032d,
-	0x00000335, 0x0000033d, 0x00000346, 0x00000352,
-	0x00000359, 0x00000361, 0x00000369, 0x00000371,
-	0x00000379, 0x0000037f, 0x00000387, 0x0000038e,
-	0x0000039d, 0x000003a4, 0x000003ac, 0x000003b4,
+	0x00000274, 0x000002c0, 0x000002f3, 0x00000320,
+	0x00000356, 0x00000390, 0x00000398, 0x000003a0,
+	0x000003a9, 0x000003b5, 0x000003bc, 0x000003c4,
+	0x000003cc, 0x000003d4, 0x000003dc, 0x000003e2,
+	0x000003ea, 0x000003f1, 0x00000400, 0x00000407,
 	// Entry 20 - 3F
-	0x000003bc, 0x000003c2, 0x000003cc, 0x000003d7,
-	0x000003de, 0x000003e9, 0x000003f9, 0x000003ff,
-	0x0000040b, 0x00000412, 0x0000041b, 0x00000423,
-	0x0000042a, 0x00000433, 0x0000043c, 0x0000044e,
-	0x00000467, 0x0000046c, 0x0000048e, 0x000004a1,
-	0x000004af, 0x000004c0, 0x000004cd, 0x000004de,
-	0x000004eb, 0x000004f8, 0x00000507, 0x00000518,
-	0x00000525, 0x0000053e, 0x0000054e, 0x00000562,
+	0x0000040f, 0x00000417, 0x0000041f, 0x00000425,
+	0x0000042f, 0x0000043a, 0x00000441, 0x0000044c,
+	0x0000045c, 0x00000462, 0x0000046e, 0x00000475,
+	0x0000047e, 0x00000486, 0x0000048d, 0x00000496,
+	0x0000049f, 0x000004b1, 0x000004ca, 0x000004cf,
+	0x000004f1, 0x00000504, 0x00000512, 0x00000523,
+	0x00000530, 0x00000541, 0x0000054e, 0x0000055b,
+	0x0000056a, 0x0000057b, 0x00000588, 0x000005a1,
 	// Entry 40 - 5F
-	0x00000570, 0x0000057d, 0x0000058b, 0x00000599,
-	0x000005ac, 0x000005c2, 0x00000660, 0x000006c1,
-	0x000006cb, 0x000006f3, 0x0000072f, 0x0000073f,
-	0x00000768, 0x000007dc, 0x00000846, 0x000008bf,
-	0x00000905, 0x00000948, 0x00000965, 0x0000096e,
-	0x000009d3, 0x000009ed, 0x000009fc, 0x00000a0a,
-	0x00000a33, 0x00000a78, 0x00000a7c, 0x00000a82,
-	0x00000a89, 0x00000a9c, 0x00000ab2, 0x00000b30,
+	0x000005b1, 0x000005c5, 0x000005d3, 0x000005e0,
+	0x000005ee, 0x000005fc, 0x0000060f, 0x00000625,
+	0x000006c3, 0x00000724, 0x0000072e, 0x00000756,
+	0x00000792, 0x000007a2, 0x000007cb, 0x0000083f,
+	0x000008a9, 0x00000922, 0x00000968, 0x000009ab,
+	0x000009c8, 0x000009d1, 0x00000a36, 0x00000a50,
+	0x00000a5f, 0x00000a6d, 0x00000a96, 0x00000adb,
+	0x00000adf, 0x00000ae5, 0x00000aec, 0x00000aff,
 	// Entry 60 - 7F
-	0x00000b3d, 0x00000b9b, 0x00000ba9, 0x00000be2,
-	0x00000ca6, 0x00000cae, 0x00000cdf, 0x00000d9d,
-	0x00000db8, 0x00000dbf, 0x00000dc5, 0x00000dcd,
-	0x00000dd2, 0x00000de5, 0x00000df0, 0x00000e59,
-	0x00000e65, 0x00000e84, 0x00000e8a, 0x00000e9f,
-	0x00000eae, 0x00000ebb, 0x00000ecf, 0x00000eed,
-	0x00000f00, 0x00000f13, 0x00000f2d, 0x00000f32,
-	0x00000f3e, 0x00000f4c, 0x00000f58, 0x00000f66,
+	0x00000b15, 0x00000b93, 0x00000ba0, 0x00000bfe,
+	0x00000c0c, 0x00000c45, 0x00000d09, 0x00000d11,
+	0x00000d42, 0x00000e00, 0x00000e1b, 0x00000e22,
+	0x00000e28, 0x00000e30, 0x00000e35, 0x00000e48,
+	0x00000e53, 0x00000ebc, 0x00000ec8, 0x00000ee7,
+	0x00000eed, 0x00000f02, 0x00000f11, 0x00000f1e,
+	0x00000f32, 0x00000f50, 0x00000f63, 0x00000f76,
+	0x00000f90, 0x00000f95, 0x00000fa1, 0x00000faf,
 	// Entry 80 - 9F
-	0x00000f76, 0x00000f85, 0x00000f90, 0x00000f9e,
-	0x00000fae, 0x00000fc7, 0x00000fe7, 0x00001016,
-	0x000010ff, 0x0000111b, 0x0000124b, 0x00001278,
-	0x000012b5, 0x0000130d, 0x00001314, 0x0000131d,
-	0x00001322, 0x00001390, 0x00001407, 0x000014cb,
-	0x00001617, 0x00001626, 0x0000162b, 0x0000163d,
-	0x00001655, 0x0000165e, 0x00001666, 0x0000167f,
-} // Size: 648 bytes
+	0x00000fbb, 0x00000fc9, 0x00000fd9, 0x00000fe8,
+	0x00000ff3, 0x00001001, 0x00001011, 0x0000102a,
+	0x0000104a, 0x00001079, 0x00001162, 0x0000117e,
+	0x000012ae, 0x000012db, 0x00001318, 0x00001370,
+	0x00001377, 0x00001380, 0x00001385, 0x000013f3,
+	0x0000146a, 0x0000152e, 0x0000167a, 0x00001689,
+	0x0000168e, 0x000016a0, 0x000016b8, 0x000016c1,
+	0x000016c9, 0x000016e2, 0x000016f6, 0x00001700,
+	// Entry A0 - BF
+	0x00001708, 0x0000170d, 0x000017aa, 0x000017c3,
+	0x000017e4, 0x00001818, 0x00001854, 0x00001885,
+	0x000018aa, 0x000018be, 0x000018f7, 0x0000191a,
+	0x00001936, 0x00001956, 0x00001960, 0x000019e0,
+	0x00001a0d, 0x00001a21, 0x00001a26, 0x00001a2e,
+	0x00001a33, 0x00001a41, 0x00001a4e, 0x00001a56,
+	0x00001a7a, 0x00001aaa, 0x00001b69, 0x00001bd7,
+	0x00001be8, 0x00001c24, 0x00001c7a, 0x00001ca3,
+	// Entry C0 - DF
+	0x00001d7d, 0x00001d91, 0x00001de5, 0x00001e53,
+	0x00001e74, 0x00001ec1, 0x00001ed1,
+} // Size: 820 bytes
 
-const en_USData string = "" + // Size: 5759 bytes
+const en_USData string = "" + // Size: 7889 bytes
 	"\x02We are waiting for your payment.\x02A payment is on the way, but we'" +
 	"re still waiting for the required amount of confirmations on the blockch" +
 	"ain.\x02We have received your payment, but have gone out of stock meanwh" +
@@ -403,81 +511,690 @@ const en_USData string = "" + // Size: 5759 bytes
 	"t, please contact us.\x02Error inserting purchase into database. Please " +
 	"try again later.\x02There is no such purchase, or it has been deleted, o" +
 	"r the URL is incorrect.\x02There is no such purchase, or it has been del" +
-	"eted.\x02Error saving contact information. Please try again later.\x02Au" +
-	"stria\x02Belgium\x02Bulgaria\x02Switzerland\x02Cyprus\x02Czechia\x02Germ" +
-	"any\x02Denmark\x02Estonia\x02Spain\x02Finland\x02France\x02United Kingdo" +
-	"m\x02Greece\x02Croatia\x02Hungary\x02Ireland\x02Italy\x02Lithuania\x02Lu" +
-	"xembourg\x02Latvia\x02Montenegro\x02North Macedonia\x02Malta\x02Netherla" +
-	"nds\x02Poland\x02Portugal\x02Romania\x02Sweden\x02Slovenia\x02Slovakia" +
-	"\x02Monero or Bitcoin\x02Cash in Foreign Currency\x02Cash\x02Bank Transf" +
-	"er to our SEPA Account\x02Australian dollars\x02Bulgarian lev\x02Canadia" +
-	"n dollars\x02Swiss francs\x02Chinese renminbi\x02Czech koruna\x02Danish " +
-	"krone\x02Pound sterling\x02Icelandic króna\x02Japanese yen\x02New Israel" +
-	"i shekel (NIS)\x02Norwegian krone\x02New Zealand dollars\x02Polish złoty" +
-	"\x02Romanian leu\x02Serbian dinar\x02Swedish krona\x02New Taiwan dollars" +
-	"\x02United States dollars\x02Buy coupons, voucher codes and gift cards f" +
-	"or privacy services and pay anonymously with Monero, Bitcoin or cash let" +
-	"ter. SEPA Bank transfer is also available.\x02Order with a few clicks. P" +
-	"ay with Monero, Bitcoin, cash in 20 currencies, or SEPA Bank transfer." +
-	"\x02Read more\x02Enter the quantity and press „Buy“.\x02Bookmark your or" +
-	"der. You will need it to access your goods.\x02Pay your order.\x02Unpaid" +
-	" orders are deleted after 30 days.\x02Monero (XMR) or Bitcoin (BTC): You" +
-	"r voucher codes are shown as soon as your payment is confirmed on the bl" +
-	"ockchain.\x02Cash: Send cash (we accept 20 currencies) to our office in " +
-	"Germany. We shred the letter after processing.\x02SEPA (Single Euro Paym" +
-	"ents Area) bank transfer to our German bank account. We manually check f" +
-	"or new payments every day.\x02Optional: Get notified by email or ntfy.sh" +
-	" when your payment arrives.\x02Write down your codes. We will delete the" +
-	"m 30 days after delivery.\x02Please select some products.\x02in stock" +
-	"\x02Where do you live? (We have to ask that for tax reasons. It does not" +
-	" affect the price or the goods.)\x02Not in the European Union\x02Europea" +
-	"n Union\x02please select\x02Please select your country of residence.\x02" +
-	"Country options are limited by your IP address and browser language.\x02" +
-	"Buy\x02Order\x02Status\x02Message from store\x02Current deletion date" +
-	"\x02JavaScript is disabled in your browser. In order to receive updates " +
-	"on your order, please reload this page from time to time.\x02What's next" +
-	"?\x02Bookmark this page or save its address in another way. You will nee" +
-	"d it to access your goods.\x02Click to copy\x02Pay your order. Unpaid or" +
-	"ders are deleted after 30 days.\x02As soon as your payment arrives, your" +
-	" voucher codes are shown. In the unlikely case that your goods have beco" +
-	"me sold out in the meantime, your codes will appear as soon as they are " +
-	"back in stock.\x02Payment\x02Optional: Get notified when your payment ar" +
-	"rives\x02Get notified when your payment arrives and your voucher codes a" +
-	"re shown. The notification will not contain the order number or the link" +
-	". Your contact information will be deleted afterwards.\x02Select notific" +
-	"ation method\x02Select\x02Email\x02Address\x02Save\x02Your Voucher Codes" +
-	"\x02Your Order\x02You will receive the missing codes here as soon as the" +
-	"y are in stock again. Sorry for the inconvenience.\x02Overall Sum\x02Sho" +
-	"w prices and delivery dates\x02Legal\x02Terms and Conditions\x02Privacy " +
-	"policy\x02Legal Notice\x02Cancellation Policy\x02Cash by mail in 18 curr" +
-	"encies\x02Monero and Bitcoin\x02SEPA bank transfer\x02All Services and P" +
-	"rojects\x02Why?\x02Local Store\x02Digital Goods\x02Online shop\x02Order " +
-	"Service\x02Online printing\x02Contact & News\x02Contact us\x02Opening ho" +
-	"urs\x02Mon+Thu 2pm-6pm\x02Tue+Wed+Fri+Sat 10am-2pm\x02See here for short" +
-	"-term changes\x02Got an idea or found an error? Drop us a note!\x02Pay w" +
-	"ith Monero (XMR) or Bitcoin (BTC). The full amount must be paid with a s" +
-	"ingle transaction to the given address within 60 minutes. If your paymen" +
-	"t arrives too late, we have to confirm it manually. If in doubt, please " +
-	"contact us.\x02Pay using Monero or Bitcoin\x02Send cash in an insured le" +
-	"tter or package to our store address in Germany. After we take out the m" +
-	"oney, we shred the letter. Please check the cash shipment limits of your" +
-	" postal company (e. g. Deutsche Post „Einschreiben Wert“ up to 100 Euros" +
-	" within Germany, DHL Parcel up to 500 Euros). Send it to:\x02Please incl" +
-	"ude a note with this order number\x02Pay the specified amount in one of " +
-	"the following currencies.\x02Please send undamaged banknotes only and ro" +
-	"und up if necessary. We do not accept coins.\x02Amount\x02Currency\x02%." +
-	"2f\x02If you are sending coins, please stick them down firmly. Otherwise" +
-	" they will be pressed out during transport.\x02We only send the order nu" +
-	"mber to PayPal. Your ordered items and delivery or pickup details will n" +
-	"ot be sent to PayPal.\x02If you use TOR or a VPN: The payment options di" +
-	"splayed depend on the country of your IP address. In addition, PayPal bl" +
-	"ocks some TOR exit nodes. In that case, try „New Circuit for this Site“." +
-	"\x02Make a bank transfer to our German SEPA (Single Euro Payments Area) " +
-	"bank account. We check for new incoming payments manually every day. We " +
-	"will see your name and account number on our bank statement. If your ban" +
-	"k account is outside the Single Euro Payments Area, please pay any fees " +
-	"yourself by selecting the „OUR“ fee option.\x02Account holder\x02IBAN" +
-	"\x02BIC (if required)\x02Bank name (if required)\x02%.2f EUR\x02Purpose" +
-	"\x02Or scan the EPC QR code:"
+	"eted.\x02Please paste exactly one OpenPGP public key.\x02This OpenPGP pu" +
+	"blic key can't be used for encryption.\x02Error saving contact informati" +
+	"on. Please try again later.\x02Austria\x02Belgium\x02Bulgaria\x02Switzer" +
+	"land\x02Cyprus\x02Czechia\x02Germany\x02Denmark\x02Estonia\x02Spain\x02F" +
+	"inland\x02France\x02United Kingdom\x02Greece\x02Croatia\x02Hungary\x02Ir" +
+	"eland\x02Italy\x02Lithuania\x02Luxembourg\x02Latvia\x02Montenegro\x02Nor" +
+	"th Macedonia\x02Malta\x02Netherlands\x02Poland\x02Portugal\x02Romania" +
+	"\x02Sweden\x02Slovenia\x02Slovakia\x02Monero or Bitcoin\x02Cash in Forei" +
+	"gn Currency\x02Cash\x02Bank Transfer to our SEPA Account\x02Australian d" +
+	"ollars\x02Bulgarian lev\x02Canadian dollars\x02Swiss francs\x02Chinese r" +
+	"enminbi\x02Czech koruna\x02Danish krone\x02Pound sterling\x02Icelandic k" +
+	"róna\x02Japanese yen\x02New Israeli shekel (NIS)\x02Norwegian krone\x02N" +
+	"ew Zealand dollars\x02Polish złoty\x02Romanian leu\x02Serbian dinar\x02S" +
+	"wedish krona\x02New Taiwan dollars\x02United States dollars\x02Buy coupo" +
+	"ns, voucher codes and gift cards for privacy services and pay anonymousl" +
+	"y with Monero, Bitcoin or cash letter. SEPA Bank transfer is also availa" +
+	"ble.\x02Order with a few clicks. Pay with Monero, Bitcoin, cash in 20 cu" +
+	"rrencies, or SEPA Bank transfer.\x02Read more\x02Enter the quantity and " +
+	"press „Buy“.\x02Bookmark your order. You will need it to access your goo" +
+	"ds.\x02Pay your order.\x02Unpaid orders are deleted after 30 days.\x02Mo" +
+	"nero (XMR) or Bitcoin (BTC): Your voucher codes are shown as soon as you" +
+	"r payment is confirmed on the blockchain.\x02Cash: Send cash (we accept " +
+	"20 currencies) to our office in Germany. We shred the letter after proce" +
+	"ssing.\x02SEPA (Single Euro Payments Area) bank transfer to our German b" +
+	"ank account. We manually check for new payments every day.\x02Optional: " +
+	"Get notified by email or ntfy.sh when your payment arrives.\x02Write dow" +
+	"n your codes. We will delete them 30 days after delivery.\x02Please sele" +
+	"ct some products.\x02in stock\x02Where do you live? (We have to ask that" +
+	" for tax reasons. It does not affect the price or the goods.)\x02Not in " +
+	"the European Union\x02European Union\x02please select\x02Please select y" +
+	"our country of residence.\x02Country options are limited by your IP addr" +
+	"ess and browser language.\x02Buy\x02Order\x02Status\x02Message from stor" +
+	"e\x02Current deletion date\x02JavaScript is disabled in your browser. In" +
+	" order to receive updates on your order, please reload this page from ti" +
+	"me to time.\x02What's next?\x02Bookmark this page or save its address in" +
+	" another way. You will need it to access your goods.\x02Click to copy" +
+	"\x02Pay your order. Unpaid orders are deleted after 30 days.\x02As soon " +
+	"as your payment arrives, your voucher codes are shown. In the unlikely c" +
+	"ase that your goods have become sold out in the meantime, your codes wil" +
+	"l appear as soon as they are back in stock.\x02Payment\x02Optional: Get " +
+	"notified when your payment arrives\x02Get notified when your payment arr" +
+	"ives and your voucher codes are shown. The notification will not contain" +
+	" the order number or the link. Your contact information will be deleted " +
+	"afterwards.\x02Select notification method\x02Select\x02Email\x02Address" +
+	"\x02Save\x02Your Voucher Codes\x02Your Order\x02You will receive the mis" +
+	"sing codes here as soon as they are in stock again. Sorry for the inconv" +
+	"enience.\x02Overall Sum\x02Show prices and delivery dates\x02Legal\x02Te" +
+	"rms and Conditions\x02Privacy policy\x02Legal Notice\x02Cancellation Pol" +
+	"icy\x02Cash by mail in 18 currencies\x02Monero and Bitcoin\x02SEPA bank " +
+	"transfer\x02All Services and Projects\x02Why?\x02Local Store\x02Digital " +
+	"Goods\x02Online shop\x02Order Service\x02Online printing\x02Contact & Ne" +
+	"ws\x02Contact us\x02Opening hours\x02Mon+Thu 2pm-6pm\x02Tue+Wed+Fri+Sat " +
+	"10am-2pm\x02See here for short-term changes\x02Got an idea or found an e" +
+	"rror? Drop us a note!\x02Pay with Monero (XMR) or Bitcoin (BTC). The ful" +
+	"l amount must be paid with a single transaction to the given address wit" +
+	"hin 60 minutes. If your payment arrives too late, we have to confirm it " +
+	"manually. If in doubt, please contact us.\x02Pay using Monero or Bitcoin" +
+	"\x02Send cash in an insured letter or package to our store address in Ge" +
+	"rmany. After we take out the money, we shred the letter. Please check th" +
+	"e cash shipment limits of your postal company (e. g. Deutsche Post „Eins" +
+	"chreiben Wert“ up to 100 Euros within Germany, DHL Parcel up to 500 Euro" +
+	"s). Send it to:\x02Please include a note with this order number\x02Pay t" +
+	"he specified amount in one of the following currencies.\x02Please send u" +
+	"ndamaged banknotes only and round up if necessary. We do not accept coin" +
+	"s.\x02Amount\x02Currency\x02%.2f\x02If you are sending coins, please sti" +
+	"ck them down firmly. Otherwise they will be pressed out during transport" +
+	".\x02We only send the order number to PayPal. Your ordered items and del" +
+	"ivery or pickup details will not be sent to PayPal.\x02If you use TOR or" +
+	" a VPN: The payment options displayed depend on the country of your IP a" +
+	"ddress. In addition, PayPal blocks some TOR exit nodes. In that case, tr" +
+	"y „New Circuit for this Site“.\x02Make a bank transfer to our German SEP" +
+	"A (Single Euro Payments Area) bank account. We check for new incoming pa" +
+	"yments manually every day. We will see your name and account number on o" +
+	"ur bank statement. If your bank account is outside the Single Euro Payme" +
+	"nts Area, please pay any fees yourself by selecting the „OUR“ fee option" +
+	".\x02Account holder\x02IBAN\x02BIC (if required)\x02Bank name (if requir" +
+	"ed)\x02%.2f EUR\x02Purpose\x02Or scan the EPC QR code:\x02Show prices al" +
+	"so in\x02Euro only\x02also in\x02Show\x02Prices in other currencies are " +
+	"indicative and based on the current exchange rate. You pay in euro, or i" +
+	"n cash at the exchange rate of the day of your order.\x02Discount code (" +
+	"optional)\x02This discount code is not valid.\x02This discount code has " +
+	"expired or is not valid yet.\x02This discount code does not apply to the" +
+	" selected products.\x02This discount code can't be used for this order." +
+	"\x02This discount code has been used up.\x02Price per item from\x02Pleas" +
+	"e check the quantities of the highlighted products.\x02Maximum number of" +
+	" items per order:\x02Maximum quantity per order:\x02Not enough in stock." +
+	" Available:\x02My orders\x02Your recent orders are stored in a cookie in" +
+	" this browser only. Bookmark your orders if you want to access them from" +
+	" elsewhere.\x02There are no orders in this browser session.\x02Download " +
+	"your codes\x02Text\x02Product\x02Code\x02Delivery date\x02Show QR code" +
+	"\x02QR code\x02Email with codes, OpenPGP encrypted\x02Optional: Receive " +
+	"your codes by encrypted email\x02Paste your OpenPGP public key and enter" +
+	" your email address above. When your codes have been delivered, we send " +
+	"them to you in an encrypted email. Your key and address are deleted afte" +
+	"rwards.\x02Your payment has been registered and is being processed. We w" +
+	"ill notify you again when it has been confirmed.\x02Payment received\x02" +
+	"We have received your payment. Your codes are listed below.\x02We have r" +
+	"eceived your payment. Please download your vouchers within the next 30 d" +
+	"ays.\x02Payment received, some items are pending\x02We have received you" +
+	"r payment. Unfortunately some of the items you ordered are out of stock." +
+	" We will deliver them as soon as possible and notify you again. You can " +
+	"already download the items which have been delivered.\x02All items deliv" +
+	"ered\x02The remaining items of your order have been delivered. Your code" +
+	"s are listed below.\x02The remaining items of your order have been deliv" +
+	"ered. Please download your vouchers within the next 30 days.\x02New mess" +
+	"age regarding your order\x02We have left a message for you on your order" +
+	" page. Please have a look at it.\x02Address formats"
 
-	// Total table size 13775 bytes (13KiB); checksum: 2AD05042
+var es_ESIndex = []uint32{ // 199 elements
+	// Entry 0 - 1F
+	0x00000000, 0x0000001b, 0x0000008b, 0x00000126,
+	0x00000148, 0x0000014e, 0x0000015e, 0x00000175,
+	0x00000180, 0x000001d1, 0x0000020e, 0x00000264,
+	0x000002b4, 0x000002fc, 0x00000327, 0x00000354,
+	0x0000038e, 0x000003d6, 0x000003de, 0x000003e7,
+	0x000003f0, 0x000003f6, 0x000003fd, 0x00000405,
+	0x0000040e, 0x00000418, 0x00000420, 0x00000428,
+	0x00000432, 0x0000043a, 0x00000446, 0x0000044d,
+	// Entry 20 - 3F
+	0x00000455, 0x0000045e, 0x00000466, 0x0000046d,
+	0x00000476, 0x00000481, 0x00000489, 0x00000494,
+	0x000004a8, 0x000004ae, 0x000004bc, 0x000004c4,
+	0x000004cd, 0x000004d6, 0x000004dd, 0x000004e7,
+	0x000004f2, 0x00000503, 0x00000521, 0x0000052a,
+	0x00000557, 0x0000056d, 0x0000057a, 0x0000058f,
+	0x0000059e, 0x000005ad, 0x000005ba, 0x000005c8,
+	0x000005d8, 0x000005e9, 0x000005f6, 0x00000613,
+	// Entry 40 - 5F
+	0x00000622, 0x00000639, 0x00000647, 0x00000652,
+	0x0000065f, 0x0000066c, 0x00000687, 0x000006a0,
+	0x0000077d, 0x000007f1, 0x000007fb, 0x00000826,
+	0x00000874, 0x00000884, 0x000008b7, 0x00000926,
+	0x000009a2, 0x00000a35, 0x00000a88, 0x00000ace,
+	0x00000aec, 0x00000af5, 0x00000b5d, 0x00000b78,
+	0x00000b87, 0x00000b92, 0x00000bb5, 0x00000c08,
+	0x00000c10, 0x00000c17, 0x00000c1e, 0x00000c33,
+	// Entry 60 - 7F
+	0x00000c50, 0x00000ccd, 0x00000cdd, 0x00000d53,
+	0x00000d68, 0x00000dab, 0x00000e6e, 0x00000e73,
+	0x00000ea3, 0x00000f56, 0x00000f75, 0x00000f81,
+	0x00000f95, 0x00000fa0, 0x00000fa8, 0x00000fbd,
+	0x00000fc7, 0x00001028, 0x00001036, 0x0000105a,
+	0x0000106d, 0x00001085, 0x0000109d, 0x000010a9,
+	0x000010c4, 0x000010e6, 0x000010f7, 0x00001113,
+	0x00001133, 0x0000113f, 0x0000114e, 0x00001162,
+	// Entry 80 - 9F
+	0x00001173, 0x00001187, 0x0000119a, 0x000011af,
+	0x000011bc, 0x000011d0, 0x000011e0, 0x000011fa,
+	0x00001225, 0x00001261, 0x0000136d, 0x00001388,
+	0x000014de, 0x0000150a, 0x00001545, 0x0000159b,
+	0x000015a3, 0x000015aa, 0x000015af, 0x0000161b,
+	0x00001699, 0x00001766, 0x000018bf, 0x000018d4,
+	0x000018d9, 0x000018ef, 0x00001912, 0x0000191b,
+	0x00001924, 0x00001941, 0x0000195d, 0x00001968,
+	// Entry A0 - BF
+	0x00001974, 0x0000197c, 0x00001a19, 0x00001a39,
+	0x00001a62, 0x00001aa2, 0x00001ae8, 0x00001b25,
+	0x00001b4e, 0x00001b66, 0x00001b9c, 0x00001bc6,
+	0x00001be3, 0x00001c08, 0x00001c14, 0x00001ca9,
+	0x00001cd7, 0x00001ced, 0x00001cf3, 0x00001cfc,
+	0x00001d04, 0x00001d15, 0x00001d28, 0x00001d33,
+	0x00001d69, 0x00001da7, 0x00001e91, 0x00001ef5,
+	0x00001f03, 0x00001f42, 0x00001f88, 0x00001fbc,
+	// Entry C0 - DF
+	0x00002097, 0x000020b7, 0x00002119, 0x00002182,
+	0x000021a0, 0x000021eb, 0x00002202,
+} // Size: 820 bytes
+
+const es_ESData string = "" + // Size: 8706 bytes
+	"\x02Estamos esperando tu pago.\x02Hay un pago en camino, pero todavía es" +
+	"tamos esperando el número necesario de confirmaciones en la blockchain." +
+	"\x02Hemos recibido tu pago, pero mientras tanto se nos ha agotado el sto" +
+	"ck. Recibirás aquí los códigos que faltan lo antes posible. Disculpa las" +
+	" molestias.\x02Tus códigos han sido entregados.\x02Nuevo\x02Pago en proc" +
+	"eso\x02Entregado parcialmente\x02Finalizado\x02Error al consultar el sto" +
+	"ck en la base de datos. Inténtalo de nuevo más tarde.\x02Error al mostra" +
+	"r la página. Inténtalo de nuevo más tarde.\x02Nuestro servicio cree que " +
+	"eres un bot. Si no lo eres, ponte en contacto con nosotros.\x02Error al " +
+	"guardar el pedido en la base de datos. Inténtalo de nuevo más tarde.\x02" +
+	"Este pedido no existe, ha sido eliminado o la dirección es incorrecta." +
+	"\x02Este pedido no existe o ha sido eliminado.\x02Pega exactamente una c" +
+	"lave pública OpenPGP.\x02Esta clave pública OpenPGP no se puede usar par" +
+	"a cifrar.\x02Error al guardar los datos de contacto. Inténtalo de nuevo " +
+	"más tarde.\x02Austria\x02Bélgica\x02Bulgaria\x02Suiza\x02Chipre\x02Chequ" +
+	"ia\x02Alemania\x02Dinamarca\x02Estonia\x02España\x02Finlandia\x02Francia" +
+	"\x02Reino Unido\x02Grecia\x02Croacia\x02Hungría\x02Irlanda\x02Italia\x02" +
+	"Lituania\x02Luxemburgo\x02Letonia\x02Montenegro\x02Macedonia del Norte" +
+	"\x02Malta\x02Países Bajos\x02Polonia\x02Portugal\x02Rumanía\x02Suecia" +
+	"\x02Eslovenia\x02Eslovaquia\x02Monero o Bitcoin\x02Efectivo en moneda ex" +
+	"tranjera\x02Efectivo\x02Transferencia bancaria a nuestra cuenta SEPA\x02" +
+	"dólares australianos\x02lev búlgaro\x02dólares canadienses\x02francos su" +
+	"izos\x02renminbi chino\x02corona checa\x02corona danesa\x02libra esterli" +
+	"na\x02corona islandesa\x02yen japonés\x02nuevo séquel israelí (NIS)\x02c" +
+	"orona noruega\x02dólares neozelandeses\x02esloti polaco\x02leu rumano" +
+	"\x02dinar serbio\x02corona sueca\x02nuevos dólares taiwaneses\x02dólares" +
+	" estadounidenses\x02Compra cupones, códigos de vale y tarjetas regalo pa" +
+	"ra servicios respetuosos con la privacidad y paga de forma anónima con M" +
+	"onero, Bitcoin o efectivo por carta. También está disponible la transfer" +
+	"encia bancaria SEPA.\x02Haz tu pedido con unos pocos clics. Paga con Mon" +
+	"ero, Bitcoin, efectivo en 20 monedas o transferencia bancaria SEPA.\x02L" +
+	"eer más\x02Introduce la cantidad y pulsa «Comprar».\x02Guarda tu pedido " +
+	"en marcadores. Lo necesitarás para acceder a tus productos.\x02Paga tu p" +
+	"edido.\x02Los pedidos no pagados se eliminan a los 30 días.\x02Monero (X" +
+	"MR) o Bitcoin (BTC): tus códigos de vale se muestran en cuanto tu pago s" +
+	"e confirma en la blockchain.\x02Efectivo: envía efectivo (aceptamos 20 m" +
+	"onedas) a nuestra oficina en Alemania. Destruimos la carta después de pr" +
+	"ocesarla.\x02Transferencia bancaria SEPA (zona única de pagos en euros) " +
+	"a nuestra cuenta bancaria alemana. Comprobamos manualmente los nuevos pa" +
+	"gos cada día.\x02Opcional: recibe un aviso por correo electrónico o ntfy" +
+	".sh cuando llegue tu pago.\x02Anota tus códigos. Los eliminaremos 30 día" +
+	"s después de la entrega.\x02Selecciona algunos productos.\x02en stock" +
+	"\x02¿Dónde vives? (Tenemos que preguntarlo por motivos fiscales. No afec" +
+	"ta al precio ni a los productos.)\x02Fuera de la Unión Europea\x02Unión " +
+	"Europea\x02selecciona\x02Selecciona tu país de residencia.\x02Los países" +
+	" disponibles dependen de tu dirección IP y del idioma de tu navegador." +
+	"\x02Comprar\x02Pedido\x02Estado\x02Mensaje de la tienda\x02Fecha de elim" +
+	"inación actual\x02JavaScript está desactivado en tu navegador. Para ver " +
+	"el estado actual de tu pedido, recarga esta página de vez en cuando.\x02" +
+	"¿Y ahora qué?\x02Guarda esta página en marcadores o guarda su dirección" +
+	" de otra forma. La necesitarás para acceder a tus productos.\x02Haz clic" +
+	" para copiar\x02Paga tu pedido. Los pedidos no pagados se eliminan a los" +
+	" 30 días.\x02En cuanto llegue tu pago, se mostrarán tus códigos de vale." +
+	" En el caso improbable de que tus productos se hayan agotado mientras ta" +
+	"nto, tus códigos aparecerán en cuanto vuelva a haber stock.\x02Pago\x02O" +
+	"pcional: recibe un aviso cuando llegue tu pago\x02Recibe un aviso cuando" +
+	" llegue tu pago y se muestren tus códigos de vale. El aviso no contendrá" +
+	" el número de pedido ni el enlace. Después se eliminarán tus datos de co" +
+	"ntacto.\x02Selecciona el método de aviso\x02Seleccionar\x02Correo electr" +
+	"ónico\x02Dirección\x02Guardar\x02Tus códigos de vale\x02Tu pedido\x02Re" +
+	"cibirás aquí los códigos que faltan en cuanto vuelva a haber stock. Disc" +
+	"ulpa las molestias.\x02Importe total\x02Mostrar precios y plazos de entr" +
+	"ega\x02Información legal\x02Términos y condiciones\x02Política de privac" +
+	"idad\x02Aviso legal\x02Política de desistimiento\x02Efectivo por correo " +
+	"en 18 monedas\x02Monero y Bitcoin\x02Transferencia bancaria SEPA\x02Todo" +
+	"s los servicios y proyectos\x02¿Por qué?\x02Tienda física\x02Productos d" +
+	"igitales\x02Tienda en línea\x02Servicio de pedidos\x02Imprenta en línea" +
+	"\x02Contacto y novedades\x02Contáctanos\x02Horario de apertura\x02lun+ju" +
+	"e 14-18 h\x02mar+mié+vie+sáb 10-14 h\x02Consulta aquí los cambios de últ" +
+	"ima hora\x02¿Tienes una idea o has encontrado un error? ¡Escríbenos!\x02" +
+	"Paga con Monero (XMR) o Bitcoin (BTC). El importe total debe pagarse en " +
+	"una sola transacción a la dirección indicada en un plazo de 60 minutos. " +
+	"Si tu pago llega demasiado tarde, tendremos que confirmarlo manualmente." +
+	" En caso de duda, ponte en contacto con nosotros.\x02Pagar con Monero o " +
+	"Bitcoin\x02Envía efectivo en una carta o paquete asegurado a la direcció" +
+	"n de nuestra tienda en Alemania. Después de sacar el dinero, destruimos " +
+	"la carta. Consulta los límites para el envío de efectivo de tu empresa d" +
+	"e correos (p. ej. Deutsche Post «Einschreiben Wert» hasta 100 euros dent" +
+	"ro de Alemania, paquete DHL hasta 500 euros). Envíalo a:\x02Incluye una " +
+	"nota con este número de pedido\x02Paga el importe indicado en una de las" +
+	" siguientes monedas.\x02Envía solo billetes en buen estado y redondea si" +
+	" es necesario. No aceptamos monedas.\x02Importe\x02Moneda\x02%.2f\x02Si " +
+	"envías monedas, pégalas firmemente. De lo contrario, podrían salirse del" +
+	" sobre durante el transporte.\x02Solo enviamos el número de pedido a Pay" +
+	"Pal. Los artículos pedidos y los datos de entrega o recogida no se envía" +
+	"n a PayPal.\x02Si usas TOR o una VPN: las opciones de pago mostradas dep" +
+	"enden del país de tu dirección IP. Además, PayPal bloquea algunos nodos " +
+	"de salida de TOR. En ese caso, prueba con «New Circuit for this Site»." +
+	"\x02Haz una transferencia bancaria a nuestra cuenta bancaria alemana SEP" +
+	"A (zona única de pagos en euros). Comprobamos manualmente los nuevos pag" +
+	"os cada día. Veremos tu nombre y tu número de cuenta en nuestro extracto" +
+	" bancario. Si tu cuenta bancaria está fuera de la zona SEPA, paga tú las" +
+	" comisiones seleccionando la opción de gastos «OUR».\x02Titular de la cu" +
+	"enta\x02IBAN\x02BIC (si es necesario)\x02Nombre del banco (si es necesar" +
+	"io)\x02%.2f €\x02Concepto\x02O escanea el código QR EPC:\x02Mostrar prec" +
+	"ios también en\x02Solo euros\x02también en\x02Mostrar\x02Los precios en " +
+	"otras monedas son orientativos y se basan en el tipo de cambio actual. P" +
+	"agas en euros, o en efectivo al tipo de cambio del día de tu pedido.\x02" +
+	"Código de descuento (opcional)\x02Este código de descuento no es válido." +
+	"\x02Este código de descuento ha caducado o todavía no es válido.\x02Este" +
+	" código de descuento no se aplica a los productos seleccionados.\x02Este" +
+	" código de descuento no se puede usar para este pedido.\x02Este código d" +
+	"e descuento se ha agotado.\x02Precio por unidad desde\x02Comprueba las c" +
+	"antidades de los productos resaltados.\x02Número máximo de artículos por" +
+	" pedido:\x02Cantidad máxima por pedido:\x02No hay suficiente stock. Disp" +
+	"onible:\x02Mis pedidos\x02Tus pedidos recientes se guardan en una cookie" +
+	" solo en este navegador. Guarda tus pedidos en marcadores si quieres acc" +
+	"eder a ellos desde otro lugar.\x02No hay pedidos en esta sesión del nave" +
+	"gador.\x02Descarga tus códigos\x02Texto\x02Producto\x02Código\x02Fecha d" +
+	"e entrega\x02Mostrar código QR\x02Código QR\x02Correo electrónico con có" +
+	"digos, cifrado con OpenPGP\x02Opcional: recibe tus códigos por correo el" +
+	"ectrónico cifrado\x02Pega tu clave pública OpenPGP e introduce tu direcc" +
+	"ión de correo electrónico arriba. Cuando tus códigos hayan sido entregad" +
+	"os, te los enviaremos en un correo electrónico cifrado. Después se elimi" +
+	"narán tu clave y tu dirección.\x02Tu pago ha sido registrado y se está p" +
+	"rocesando. Te avisaremos de nuevo cuando se haya confirmado.\x02Pago rec" +
+	"ibido\x02Hemos recibido tu pago. Tus códigos aparecen a continuación." +
+	"\x02Hemos recibido tu pago. Descarga tus vales en los próximos 30 días." +
+	"\x02Pago recibido, algunos artículos están pendientes\x02Hemos recibido " +
+	"tu pago. Lamentablemente, algunos de los artículos que has pedido están " +
+	"agotados. Los entregaremos lo antes posible y te avisaremos de nuevo. Ya" +
+	" puedes descargar los artículos que han sido entregados.\x02Todos los ar" +
+	"tículos entregados\x02Los artículos restantes de tu pedido han sido entr" +
+	"egados. Tus códigos aparecen a continuación.\x02Los artículos restantes " +
+	"de tu pedido han sido entregados. Descarga tus vales en los próximos 30 " +
+	"días.\x02Nuevo mensaje sobre tu pedido\x02Te hemos dejado un mensaje en " +
+	"la página de tu pedido. Échale un vistazo.\x02Formatos de dirección"
+
+var fr_FRIndex = []uint32{ // 199 elements
+	// Entry 0 - 1F
+	0x00000000, 0x0000001f, 0x00000089, 0x0000013f,
+	0x0000015c, 0x00000165, 0x00000185, 0x0000019b,
+	0x000001a5, 0x00000201, 0x00000244, 0x000002a2,
+	0x0000030a, 0x00000358, 0x0000038b, 0x000003c1,
+	0x0000040e, 0x0000045f, 0x00000468, 0x00000471,
+	0x0000047a, 0x00000481, 0x00000488, 0x00000492,
+	0x0000049c, 0x000004a5, 0x000004ad, 0x000004b5,
+	0x000004be, 0x000004c5, 0x000004d1, 0x000004d8,
+	// Entry 20 - 3F
+	0x000004e0, 0x000004e8, 0x000004f0, 0x000004f7,
+	0x00000500, 0x0000050b, 0x00000514, 0x00000521,
+	0x00000534, 0x0000053a, 0x00000543, 0x0000054b,
+	0x00000554, 0x0000055d, 0x00000564, 0x0000056e,
+	0x00000578, 0x0000058a, 0x000005a9, 0x000005b2,
+	0x000005da, 0x000005ee, 0x000005fa, 0x0000060c,
+	0x0000061b, 0x0000062c, 0x0000063e, 0x0000064f,
+	0x0000065e, 0x00000672, 0x0000067f, 0x0000069f,
+	// Entry 40 - 5F
+	0x000006b5, 0x000006cd, 0x000006dd, 0x000006e9,
+	0x000006f5, 0x00000708, 0x00000724, 0x00000738,
+	0x00000821, 0x00000896, 0x000008a5, 0x000008da,
+	0x00000935, 0x0000094b, 0x0000098b, 0x00000a01,
+	0x00000a89, 0x00000b2a, 0x00000b79, 0x00000bbc,
+	0x00000be1, 0x00000bea, 0x00000c6d, 0x00000c89,
+	0x00000c9b, 0x00000cb2, 0x00000ce3, 0x00000d3a,
+	0x00000d42, 0x00000d4b, 0x00000d52, 0x00000d69,
+	// Entry 60 - 7F
+	0x00000d86, 0x00000e10, 0x00000e20, 0x00000ea7,
+	0x00000ebb, 0x00000f11, 0x00000fd8, 0x00000fe1,
+	0x00001019, 0x000010da, 0x000010fa, 0x00001102,
+	0x00001109, 0x00001111, 0x0000111d, 0x0000112e,
+	0x0000113d, 0x000011b8, 0x000011c6, 0x000011f4,
+	0x0000120a, 0x0000122a, 0x00001248, 0x0000125a,
+	0x00001271, 0x00001297, 0x000012a9, 0x000012c0,
+	0x000012dd, 0x000012e8, 0x000012f0, 0x00001305,
+	// Entry 80 - 9F
+	0x00001317, 0x0000132b, 0x0000133f, 0x00001356,
+	0x00001365, 0x0000137a, 0x0000138a, 0x000013a2,
+	0x000013d4, 0x00001415, 0x00001525, 0x00001543,
+	0x000016af, 0x000016e5, 0x00001721, 0x0000178a,
+	0x00001792, 0x00001799, 0x0000179e, 0x0000181d,
+	0x000018bf, 0x00001995, 0x00001b21, 0x00001b35,
+	0x00001b3a, 0x00001b4f, 0x00001b71, 0x00001b7a,
+	0x00001b8c, 0x00001ba8, 0x00001bc3, 0x00001bd3,
+	// Entry A0 - BF
+	0x00001bdc, 0x00001be5, 0x00001c8d, 0x00001cad,
+	0x00001cd5, 0x00001d11, 0x00001d56, 0x00001d9c,
+	0x00001dd1, 0x00001dec, 0x00001e2d, 0x00001e56,
+	0x00001e78, 0x00001e98, 0x00001ea6, 0x00001f55,
+	0x00001f90, 0x00001fa8, 0x00001fae, 0x00001fb6,
+	0x00001fbb, 0x00001fcd, 0x00001fe1, 0x00001fe9,
+	0x00002016, 0x0000204a, 0x0000212b, 0x000021ad,
+	0x000021bc, 0x000021fc, 0x0000225a, 0x0000228c,
+	// Entry C0 - DF
+	0x00002382, 0x000023a6, 0x00002400, 0x00002478,
+	0x000024a2, 0x000024fb, 0x0000250d,
+} // Size: 820 bytes
+
+const fr_FRData string = "" + // Size: 9485 bytes
+	"\x02Nous attendons votre paiement.\x02Un paiement est en route, mais nou" +
+	"s attendons encore le nombre requis de confirmations sur la blockchain." +
+	"\x02Nous avons reçu votre paiement, mais notre stock a été épuisé entre-" +
+	"temps. Vous recevrez ici les codes manquants dès que possible. Veuillez " +
+	"nous excuser pour ce désagrément.\x02Vos codes ont été livrés.\x02Nouvel" +
+	"le\x02Paiement en cours de traitement\x02Livrée partiellement\x02Terminé" +
+	"e\x02Erreur lors de la lecture du stock dans la base de données. Veuille" +
+	"z réessayer plus tard.\x02Erreur lors de l'affichage du site. Veuillez r" +
+	"éessayer plus tard.\x02Notre service pense que vous êtes un robot. Si c" +
+	"e n'est pas le cas, veuillez nous contacter.\x02Erreur lors de l'enregis" +
+	"trement de la commande dans la base de données. Veuillez réessayer plus " +
+	"tard.\x02Cette commande n'existe pas, a été supprimée, ou l'adresse est " +
+	"incorrecte.\x02Cette commande n'existe pas ou a été supprimée.\x02Veuill" +
+	"ez coller exactement une clé publique OpenPGP.\x02Cette clé publique Ope" +
+	"nPGP ne peut pas être utilisée pour le chiffrement.\x02Erreur lors de l'" +
+	"enregistrement des coordonnées. Veuillez réessayer plus tard.\x02Autrich" +
+	"e\x02Belgique\x02Bulgarie\x02Suisse\x02Chypre\x02Tchéquie\x02Allemagne" +
+	"\x02Danemark\x02Estonie\x02Espagne\x02Finlande\x02France\x02Royaume-Uni" +
+	"\x02Grèce\x02Croatie\x02Hongrie\x02Irlande\x02Italie\x02Lituanie\x02Luxe" +
+	"mbourg\x02Lettonie\x02Monténégro\x02Macédoine du Nord\x02Malte\x02Pays-B" +
+	"as\x02Pologne\x02Portugal\x02Roumanie\x02Suède\x02Slovénie\x02Slovaquie" +
+	"\x02Monero ou Bitcoin\x02Espèces en devise étrangère\x02Espèces\x02Virem" +
+	"ent bancaire sur notre compte SEPA\x02dollars australiens\x02lev bulgare" +
+	"\x02dollars canadiens\x02francs suisses\x02renminbi chinois\x02couronne " +
+	"tchèque\x02couronne danoise\x02livre sterling\x02couronne islandaise\x02" +
+	"yen japonais\x02nouveau shekel israélien (NIS)\x02couronne norvégienne" +
+	"\x02dollars néo-zélandais\x02złoty polonais\x02leu roumain\x02dinar serb" +
+	"e\x02couronne suédoise\x02nouveaux dollars de Taïwan\x02dollars américai" +
+	"ns\x02Achetez des coupons, des codes de bon et des cartes cadeaux pour d" +
+	"es services respectueux de la vie privée et payez anonymement en Monero," +
+	" en Bitcoin ou en espèces par courrier. Le virement bancaire SEPA est ég" +
+	"alement disponible.\x02Commandez en quelques clics. Payez en Monero, en " +
+	"Bitcoin, en espèces dans 20 devises ou par virement bancaire SEPA.\x02En" +
+	" savoir plus\x02Saisissez la quantité et cliquez sur « Acheter ».\x02Ajo" +
+	"utez votre commande à vos favoris. Vous en aurez besoin pour accéder à v" +
+	"os produits.\x02Payez votre commande.\x02Les commandes non payées sont s" +
+	"upprimées au bout de 30 jours.\x02Monero (XMR) ou Bitcoin (BTC) : vos co" +
+	"des de bon s'affichent dès que votre paiement est confirmé sur la blockc" +
+	"hain.\x02Espèces : envoyez des espèces (nous acceptons 20 devises) à not" +
+	"re bureau en Allemagne. Nous détruisons la lettre après traitement.\x02V" +
+	"irement bancaire SEPA (espace unique de paiement en euros) sur notre com" +
+	"pte bancaire allemand. Nous vérifions manuellement les nouveaux paiement" +
+	"s chaque jour.\x02Facultatif : soyez averti par e-mail ou ntfy.sh lorsqu" +
+	"e votre paiement arrive.\x02Notez vos codes. Nous les supprimons 30 jour" +
+	"s après la livraison.\x02Veuillez sélectionner des produits.\x02en stock" +
+	"\x02Où habitez-vous ? (Nous devons le demander pour des raisons fiscales" +
+	". Cela n'a aucune incidence sur le prix ni sur les produits.)\x02Hors de" +
+	" l'Union européenne\x02Union européenne\x02veuillez sélectionner\x02Veui" +
+	"llez sélectionner votre pays de résidence.\x02Les pays proposés dépenden" +
+	"t de votre adresse IP et de la langue de votre navigateur.\x02Acheter" +
+	"\x02Commande\x02Statut\x02Message de la boutique\x02Date de suppression " +
+	"actuelle\x02JavaScript est désactivé dans votre navigateur. Pour suivre " +
+	"l'état de votre commande, veuillez recharger cette page de temps en temp" +
+	"s.\x02Et maintenant ?\x02Ajoutez cette page à vos favoris ou enregistrez" +
+	" son adresse d'une autre manière. Vous en aurez besoin pour accéder à vo" +
+	"s produits.\x02Cliquer pour copier\x02Payez votre commande. Les commande" +
+	"s non payées sont supprimées au bout de 30 jours.\x02Vos codes de bon s'" +
+	"affichent dès que votre paiement arrive. Dans le cas peu probable où vos" +
+	" produits seraient épuisés entre-temps, vos codes apparaîtront dès qu'il" +
+	"s seront de nouveau en stock.\x02Paiement\x02Facultatif : soyez averti l" +
+	"orsque votre paiement arrive\x02Soyez averti lorsque votre paiement arri" +
+	"ve et que vos codes de bon s'affichent. La notification ne contiendra ni" +
+	" le numéro de commande ni le lien. Vos coordonnées seront ensuite suppri" +
+	"mées.\x02Choisir le mode de notification\x02Choisir\x02E-mail\x02Adresse" +
+	"\x02Enregistrer\x02Vos codes de bon\x02Votre commande\x02Vous recevrez i" +
+	"ci les codes manquants dès qu'ils seront de nouveau en stock. Veuillez n" +
+	"ous excuser pour ce désagrément.\x02Montant total\x02Afficher les prix e" +
+	"t les délais de livraison\x02Informations légales\x02Conditions générale" +
+	"s de vente\x02Politique de confidentialité\x02Mentions légales\x02Droit " +
+	"de rétractation\x02Espèces par courrier dans 18 devises\x02Monero et Bit" +
+	"coin\x02Virement bancaire SEPA\x02Tous les services et projets\x02Pourqu" +
+	"oi ?\x02Magasin\x02Produits numériques\x02Boutique en ligne\x02Service d" +
+	"e commande\x02Imprimerie en ligne\x02Contact et actualités\x02Nous conta" +
+	"cter\x02Horaires d'ouverture\x02lun+jeu 14h-18h\x02mar+mer+ven+sam 10h-1" +
+	"4h\x02Voir ici pour les changements de dernière minute\x02Vous avez une " +
+	"idée ou avez trouvé une erreur ? Écrivez-nous !\x02Payez en Monero (XMR)" +
+	" ou en Bitcoin (BTC). Le montant total doit être payé en une seule trans" +
+	"action à l'adresse indiquée dans un délai de 60 minutes. Si votre paieme" +
+	"nt arrive trop tard, nous devons le confirmer manuellement. En cas de do" +
+	"ute, veuillez nous contacter.\x02Payer en Monero ou en Bitcoin\x02Envoye" +
+	"z des espèces dans une lettre ou un colis assuré à l'adresse de notre ma" +
+	"gasin en Allemagne. Après avoir retiré l'argent, nous détruisons la lett" +
+	"re. Veuillez vérifier les limites d'envoi d'espèces de votre entreprise " +
+	"postale (p. ex. Deutsche Post « Einschreiben Wert » jusqu'à 100 euros en" +
+	" Allemagne, colis DHL jusqu'à 500 euros). Envoyez-le à :\x02Veuillez joi" +
+	"ndre une note avec ce numéro de commande\x02Payez le montant indiqué dan" +
+	"s l'une des devises suivantes.\x02Veuillez n'envoyer que des billets en " +
+	"bon état et arrondir au besoin. Nous n'acceptons pas les pièces.\x02Mont" +
+	"ant\x02Devise\x02%.2f\x02Si vous envoyez des pièces, veuillez les coller" +
+	" solidement. Sinon, elles risquent de percer l'enveloppe pendant le tran" +
+	"sport.\x02Nous transmettons uniquement le numéro de commande à PayPal. L" +
+	"es articles commandés et les détails de livraison ou de retrait ne sont " +
+	"pas transmis à PayPal.\x02Si vous utilisez TOR ou un VPN : les moyens de" +
+	" paiement affichés dépendent du pays de votre adresse IP. De plus, PayPa" +
+	"l bloque certains nœuds de sortie TOR. Dans ce cas, essayez « New Circui" +
+	"t for this Site ».\x02Effectuez un virement bancaire sur notre compte ba" +
+	"ncaire allemand SEPA (espace unique de paiement en euros). Nous vérifion" +
+	"s manuellement les nouveaux paiements chaque jour. Nous verrons votre no" +
+	"m et votre numéro de compte sur notre relevé bancaire. Si votre compte b" +
+	"ancaire se trouve en dehors de l'espace SEPA, veuillez prendre en charge" +
+	" les frais en choisissant l'option de frais « OUR ».\x02Titulaire du com" +
+	"pte\x02IBAN\x02BIC (si nécessaire)\x02Nom de la banque (si nécessaire)" +
+	"\x02%.2f €\x02Motif du virement\x02Ou scannez le code QR EPC :\x02Affich" +
+	"er aussi les prix en\x02Euro uniquement\x02aussi en\x02Afficher\x02Les p" +
+	"rix dans d'autres devises sont indicatifs et basés sur le taux de change" +
+	" actuel. Vous payez en euros, ou en espèces au taux de change du jour de" +
+	" votre commande.\x02Code de réduction (facultatif)\x02Ce code de réducti" +
+	"on n'est pas valide.\x02Ce code de réduction a expiré ou n'est pas encor" +
+	"e valide.\x02Ce code de réduction ne s'applique pas aux produits sélecti" +
+	"onnés.\x02Ce code de réduction ne peut pas être utilisé pour cette comma" +
+	"nde.\x02Ce code de réduction a été entièrement utilisé.\x02Prix unitaire" +
+	" à partir de\x02Veuillez vérifier les quantités des produits mis en évid" +
+	"ence.\x02Nombre maximal d'articles par commande :\x02Quantité maximale p" +
+	"ar commande :\x02Stock insuffisant. Disponible :\x02Mes commandes\x02Vos" +
+	" commandes récentes sont enregistrées dans un cookie de ce navigateur un" +
+	"iquement. Ajoutez vos commandes à vos favoris si vous voulez y accéder d" +
+	"epuis un autre endroit.\x02Il n'y a aucune commande dans cette session d" +
+	"u navigateur.\x02Télécharger vos codes\x02Texte\x02Produit\x02Code\x02Da" +
+	"te de livraison\x02Afficher le code QR\x02Code QR\x02E-mail avec les cod" +
+	"es, chiffré avec OpenPGP\x02Facultatif : recevoir vos codes par e-mail c" +
+	"hiffré\x02Collez votre clé publique OpenPGP et saisissez votre adresse e" +
+	"-mail ci-dessus. Lorsque vos codes auront été livrés, nous vous les enve" +
+	"rrons dans un e-mail chiffré. Votre clé et votre adresse seront ensuite " +
+	"supprimées.\x02Votre paiement a été enregistré et est en cours de traite" +
+	"ment. Nous vous avertirons à nouveau lorsqu'il aura été confirmé.\x02Pai" +
+	"ement reçu\x02Nous avons reçu votre paiement. Vos codes figurent ci-dess" +
+	"ous.\x02Nous avons reçu votre paiement. Veuillez télécharger vos bons da" +
+	"ns les 30 prochains jours.\x02Paiement reçu, certains articles sont en a" +
+	"ttente\x02Nous avons reçu votre paiement. Malheureusement, certains des " +
+	"articles commandés sont en rupture de stock. Nous les livrerons dès que " +
+	"possible et vous avertirons à nouveau. Vous pouvez déjà télécharger les " +
+	"articles qui ont été livrés.\x02Tous les articles ont été livrés\x02Les " +
+	"articles restants de votre commande ont été livrés. Vos codes figurent c" +
+	"i-dessous.\x02Les articles restants de votre commande ont été livrés. Ve" +
+	"uillez télécharger vos bons dans les 30 prochains jours.\x02Nouveau mess" +
+	"age concernant votre commande\x02Nous vous avons laissé un message sur l" +
+	"a page de votre commande. Veuillez le consulter.\x02Formats d'adresse"
+
+var it_ITIndex = []uint32{ // 199 elements
+	// Entry 0 - 1F
+	0x00000000, 0x00000024, 0x0000008d, 0x00000132,
+	0x00000157, 0x0000015d, 0x00000177, 0x0000018b,
+	0x00000196, 0x000001df, 0x00000223, 0x0000026a,
+	0x000002b6, 0x00000307, 0x00000336, 0x00000367,
+	0x000003ae, 0x000003f6, 0x000003fe, 0x00000405,
+	0x0000040e, 0x00000417, 0x0000041d, 0x00000424,
+	0x0000042d, 0x00000437, 0x0000043f, 0x00000446,
+	0x00000450, 0x00000458, 0x00000464, 0x0000046b,
+	// Entry 20 - 3F
+	0x00000473, 0x0000047c, 0x00000484, 0x0000048b,
+	0x00000494, 0x000004a0, 0x000004a9, 0x000004b4,
+	0x000004c7, 0x000004cd, 0x000004d9, 0x000004e1,
+	0x000004ec, 0x000004f4, 0x000004fb, 0x00000504,
+	0x0000050f, 0x00000520, 0x0000053a, 0x00000543,
+	0x0000056b, 0x0000057f, 0x0000058b, 0x0000059c,
+	0x000005ad, 0x000005bd, 0x000005c9, 0x000005d7,
+	0x000005eb, 0x000005fc, 0x0000060b, 0x00000628,
+	// Entry 40 - 5F
+	0x00000639, 0x0000064e, 0x0000065d, 0x00000668,
+	0x00000675, 0x00000684, 0x0000069c, 0x000006b1,
+	0x00000778, 0x000007d9, 0x000007e7, 0x00000814,
+	0x00000863, 0x00000877, 0x000008af, 0x0000092c,
+	0x000009a8, 0x00000a3d, 0x00000a8f, 0x00000acf,
+	0x00000aea, 0x00000af6, 0x00000b55, 0x00000b6f,
+	0x00000b7e, 0x00000b88, 0x00000bad, 0x00000c00,
+	0x00000c09, 0x00000c10, 0x00000c16, 0x00000c2c,
+	// Entry 60 - 7F
+	0x00000c49, 0x00000ccd, 0x00000cd7, 0x00000d53,
+	0x00000d66, 0x00000db2, 0x00000e89, 0x00000e93,
+	0x00000ed0, 0x00000f91, 0x00000faf, 0x00000fb9,
+	0x00000fbf, 0x00000fc9, 0x00000fcf, 0x00000fe5,
+	0x00000ff3, 0x0000105d, 0x00001070, 0x00001092,
+	0x0000109e, 0x000010b3, 0x000010cd, 0x000010d7,
+	0x000010ea, 0x0000110a, 0x0000111b, 0x00001132,
+	0x0000114d, 0x00001156, 0x00001165, 0x00001177,
+	// Entry 80 - 9F
+	0x00001186, 0x00001196, 0x000011a8, 0x000011bb,
+	0x000011c6, 0x000011d8, 0x000011e6, 0x000011fc,
+	0x00001229, 0x00001258, 0x0000134d, 0x00001367,
+	0x000014bd, 0x000014ec, 0x00001522, 0x00001571,
+	0x00001579, 0x00001580, 0x00001585, 0x000015fa,
+	0x00001676, 0x0000173c, 0x0000189d, 0x000018b4,
+	0x000018b9, 0x000018cc, 0x000018ec, 0x000018f5,
+	0x000018fd, 0x00001920, 0x00001939, 0x00001943,
+	// Entry A0 - BF
+	0x0000194c, 0x00001953, 0x000019f2, 0x00001a0e,
+	0x00001a32, 0x00001a6a, 0x00001aa7, 0x00001ae5,
+	0x00001b07, 0x00001b1a, 0x00001b4b, 0x00001b72,
+	0x00001b90, 0x00001bb3, 0x00001bc1, 0x00001c4d,
+	0x00001c80, 0x00001c96, 0x00001c9c, 0x00001ca5,
+	0x00001cac, 0x00001cbd, 0x00001cce, 0x00001cd8,
+	0x00001d00, 0x00001d34, 0x00001e0d, 0x00001e79,
+	0x00001e8c, 0x00001ed6, 0x00001f2c, 0x00001f60,
+	// Entry C0 - DF
+	0x0000203c, 0x0000205a, 0x000020be, 0x0000212e,
+	0x0000214d, 0x0000219e, 0x000021b6,
+} // Size: 820 bytes
+
+const it_ITData string = "" + // Size: 8630 bytes
+	"\x02Stiamo aspettando il tuo pagamento.\x02Un pagamento è in arrivo, ma " +
+	"stiamo ancora aspettando il numero richiesto di conferme sulla blockchai" +
+	"n.\x02Abbiamo ricevuto il tuo pagamento, ma nel frattempo le scorte si s" +
+	"ono esaurite. Riceverai qui i codici mancanti il prima possibile. Ci scu" +
+	"siamo per l'inconveniente.\x02I tuoi codici sono stati consegnati.\x02Nu" +
+	"ovo\x02Pagamento in elaborazione\x02Consegnato in parte\x02Completato" +
+	"\x02Errore durante la lettura delle scorte dal database. Riprova più tar" +
+	"di.\x02Errore durante la visualizzazione della pagina. Riprova più tardi" +
+	".\x02Il nostro servizio pensa che tu sia un bot. Se non lo sei, contatta" +
+	"ci.\x02Errore durante il salvataggio dell'ordine nel database. Riprova p" +
+	"iù tardi.\x02Questo ordine non esiste, è stato eliminato oppure l'indiri" +
+	"zzo non è corretto.\x02Questo ordine non esiste o è stato eliminato.\x02" +
+	"Incolla esattamente una chiave pubblica OpenPGP.\x02Questa chiave pubbli" +
+	"ca OpenPGP non può essere usata per la cifratura.\x02Errore durante il s" +
+	"alvataggio dei dati di contatto. Riprova più tardi.\x02Austria\x02Belgio" +
+	"\x02Bulgaria\x02Svizzera\x02Cipro\x02Cechia\x02Germania\x02Danimarca\x02" +
+	"Estonia\x02Spagna\x02Finlandia\x02Francia\x02Regno Unito\x02Grecia\x02Cr" +
+	"oazia\x02Ungheria\x02Irlanda\x02Italia\x02Lituania\x02Lussemburgo\x02Let" +
+	"tonia\x02Montenegro\x02Macedonia del Nord\x02Malta\x02Paesi Bassi\x02Pol" +
+	"onia\x02Portogallo\x02Romania\x02Svezia\x02Slovenia\x02Slovacchia\x02Mon" +
+	"ero o Bitcoin\x02Contanti in valuta estera\x02Contanti\x02Bonifico banca" +
+	"rio sul nostro conto SEPA\x02dollari australiani\x02lev bulgaro\x02dolla" +
+	"ri canadesi\x02franchi svizzeri\x02renminbi cinese\x02corona ceca\x02cor" +
+	"ona danese\x02sterlina britannica\x02corona islandese\x02yen giapponese" +
+	"\x02nuovo siclo israeliano (NIS)\x02corona norvegese\x02dollari neozelan" +
+	"desi\x02złoty polacco\x02leu rumeno\x02dinaro serbo\x02corona svedese" +
+	"\x02nuovi dollari taiwanesi\x02dollari statunitensi\x02Acquista coupon, " +
+	"codici voucher e carte regalo per servizi rispettosi della privacy e pag" +
+	"a in modo anonimo con Monero, Bitcoin o contanti per lettera. È disponib" +
+	"ile anche il bonifico bancario SEPA.\x02Ordina con pochi clic. Paga con " +
+	"Monero, Bitcoin, contanti in 20 valute o bonifico bancario SEPA.\x02Legg" +
+	"i di più\x02Inserisci la quantità e premi «Acquista».\x02Salva il tuo or" +
+	"dine nei segnalibri. Ti servirà per accedere ai tuoi prodotti.\x02Paga i" +
+	"l tuo ordine.\x02Gli ordini non pagati vengono eliminati dopo 30 giorni." +
+	"\x02Monero (XMR) o Bitcoin (BTC): i tuoi codici voucher vengono mostrati" +
+	" non appena il pagamento è confermato sulla blockchain.\x02Contanti: inv" +
+	"ia contanti (accettiamo 20 valute) al nostro ufficio in Germania. Distru" +
+	"ggiamo la lettera dopo l'elaborazione.\x02Bonifico bancario SEPA (area u" +
+	"nica dei pagamenti in euro) sul nostro conto bancario tedesco. Controlli" +
+	"amo manualmente i nuovi pagamenti ogni giorno.\x02Facoltativo: ricevi un" +
+	" avviso via email o ntfy.sh quando arriva il tuo pagamento.\x02Annota i " +
+	"tuoi codici. Li eliminiamo 30 giorni dopo la consegna.\x02Seleziona alcu" +
+	"ni prodotti.\x02disponibile\x02Dove vivi? (Dobbiamo chiederlo per motivi" +
+	" fiscali. Non influisce sul prezzo né sui prodotti.)\x02Fuori dall'Union" +
+	"e Europea\x02Unione Europea\x02seleziona\x02Seleziona il tuo paese di re" +
+	"sidenza.\x02I paesi disponibili dipendono dal tuo indirizzo IP e dalla l" +
+	"ingua del tuo browser.\x02Acquista\x02Ordine\x02Stato\x02Messaggio del n" +
+	"egozio\x02Data di eliminazione attuale\x02JavaScript è disattivato nel t" +
+	"uo browser. Per vedere lo stato aggiornato del tuo ordine, ricarica ques" +
+	"ta pagina di tanto in tanto.\x02E adesso?\x02Salva questa pagina nei seg" +
+	"nalibri o conserva il suo indirizzo in un altro modo. Ti servirà per acc" +
+	"edere ai tuoi prodotti.\x02Clicca per copiare\x02Paga il tuo ordine. Gli" +
+	" ordini non pagati vengono eliminati dopo 30 giorni.\x02Non appena arriv" +
+	"a il tuo pagamento, vengono mostrati i tuoi codici voucher. Nel caso imp" +
+	"robabile in cui i tuoi prodotti si siano esauriti nel frattempo, i codic" +
+	"i appariranno non appena saranno di nuovo disponibili.\x02Pagamento\x02F" +
+	"acoltativo: ricevi un avviso quando arriva il tuo pagamento\x02Ricevi un" +
+	" avviso quando arriva il tuo pagamento e vengono mostrati i tuoi codici " +
+	"voucher. L'avviso non conterrà il numero d'ordine né il link. I tuoi dat" +
+	"i di contatto verranno poi eliminati.\x02Seleziona il metodo di avviso" +
+	"\x02Seleziona\x02Email\x02Indirizzo\x02Salva\x02I tuoi codici voucher" +
+	"\x02Il tuo ordine\x02Riceverai qui i codici mancanti non appena saranno " +
+	"di nuovo disponibili. Ci scusiamo per l'inconveniente.\x02Totale comples" +
+	"sivo\x02Mostra prezzi e tempi di consegna\x02Note legali\x02Termini e co" +
+	"ndizioni\x02Informativa sulla privacy\x02Impressum\x02Diritto di recesso" +
+	"\x02Contanti per posta in 18 valute\x02Monero e Bitcoin\x02Bonifico banc" +
+	"ario SEPA\x02Tutti i servizi e progetti\x02Perché?\x02Negozio fisico\x02" +
+	"Prodotti digitali\x02Negozio online\x02Servizio ordini\x02Tipografia onl" +
+	"ine\x02Contatti e novità\x02Contattaci\x02Orari di apertura\x02lun+gio 1" +
+	"4-18\x02mar+mer+ven+sab 10-14\x02Vedi qui per le modifiche dell'ultimo m" +
+	"inuto\x02Hai un'idea o hai trovato un errore? Scrivici!\x02Paga con Mone" +
+	"ro (XMR) o Bitcoin (BTC). L'importo totale deve essere pagato con un'uni" +
+	"ca transazione all'indirizzo indicato entro 60 minuti. Se il tuo pagamen" +
+	"to arriva troppo tardi, dobbiamo confermarlo manualmente. In caso di dub" +
+	"bi, contattaci.\x02Paga con Monero o Bitcoin\x02Invia contanti in una le" +
+	"ttera o in un pacco assicurato all'indirizzo del nostro negozio in Germa" +
+	"nia. Dopo aver prelevato il denaro, distruggiamo la lettera. Verifica i " +
+	"limiti per la spedizione di contanti del tuo servizio postale (ad es. De" +
+	"utsche Post «Einschreiben Wert» fino a 100 euro in Germania, pacco DHL f" +
+	"ino a 500 euro). Invialo a:\x02Allega un biglietto con questo numero d'o" +
+	"rdine\x02Paga l'importo indicato in una delle seguenti valute.\x02Invia " +
+	"solo banconote integre e arrotonda se necessario. Non accettiamo monete." +
+	"\x02Importo\x02Valuta\x02%.2f\x02Se invii monete, fissale bene con del n" +
+	"astro adesivo. Altrimenti potrebbero uscire dalla busta durante il trasp" +
+	"orto.\x02Inviamo a PayPal solo il numero d'ordine. Gli articoli ordinati" +
+	" e i dati di consegna o ritiro non vengono inviati a PayPal.\x02Se usi T" +
+	"OR o una VPN: i metodi di pagamento mostrati dipendono dal paese del tuo" +
+	" indirizzo IP. Inoltre PayPal blocca alcuni nodi di uscita TOR. In tal c" +
+	"aso, prova con «New Circuit for this Site».\x02Effettua un bonifico banc" +
+	"ario sul nostro conto bancario tedesco SEPA (area unica dei pagamenti in" +
+	" euro). Controlliamo manualmente i nuovi pagamenti ogni giorno. Vedremo " +
+	"il tuo nome e il tuo numero di conto sul nostro estratto conto. Se il tu" +
+	"o conto bancario si trova fuori dall'area SEPA, paga tu le commissioni s" +
+	"elezionando l'opzione di spesa «OUR».\x02Intestatario del conto\x02IBAN" +
+	"\x02BIC (se richiesto)\x02Nome della banca (se richiesto)\x02%.2f €\x02C" +
+	"ausale\x02Oppure scansiona il codice QR EPC:\x02Mostra i prezzi anche in" +
+	"\x02Solo euro\x02anche in\x02Mostra\x02I prezzi in altre valute sono ind" +
+	"icativi e basati sul tasso di cambio attuale. Paghi in euro, oppure in c" +
+	"ontanti al tasso di cambio del giorno del tuo ordine.\x02Codice sconto (" +
+	"facoltativo)\x02Questo codice sconto non è valido.\x02Questo codice scon" +
+	"to è scaduto o non è ancora valido.\x02Questo codice sconto non si appli" +
+	"ca ai prodotti selezionati.\x02Questo codice sconto non può essere usato" +
+	" per questo ordine.\x02Questo codice sconto è esaurito.\x02Prezzo unitar" +
+	"io da\x02Controlla le quantità dei prodotti evidenziati.\x02Numero massi" +
+	"mo di articoli per ordine:\x02Quantità massima per ordine:\x02Scorte ins" +
+	"ufficienti. Disponibili:\x02I miei ordini\x02I tuoi ordini recenti vengo" +
+	"no salvati in un cookie solo in questo browser. Salva i tuoi ordini nei " +
+	"segnalibri se vuoi accedervi da altrove.\x02Non ci sono ordini in questa" +
+	" sessione del browser.\x02Scarica i tuoi codici\x02Testo\x02Prodotto\x02" +
+	"Codice\x02Data di consegna\x02Mostra codice QR\x02Codice QR\x02Email con" +
+	" i codici, cifrata con OpenPGP\x02Facoltativo: ricevi i tuoi codici via " +
+	"email cifrata\x02Incolla la tua chiave pubblica OpenPGP e inserisci il t" +
+	"uo indirizzo email qui sopra. Quando i tuoi codici saranno stati consegn" +
+	"ati, te li invieremo in un'email cifrata. La chiave e l'indirizzo verran" +
+	"no poi eliminati.\x02Il tuo pagamento è stato registrato ed è in elabora" +
+	"zione. Ti avviseremo di nuovo quando sarà confermato.\x02Pagamento ricev" +
+	"uto\x02Abbiamo ricevuto il tuo pagamento. I tuoi codici sono elencati qu" +
+	"i sotto.\x02Abbiamo ricevuto il tuo pagamento. Scarica i tuoi voucher en" +
+	"tro i prossimi 30 giorni.\x02Pagamento ricevuto, alcuni articoli sono in" +
+	" sospeso\x02Abbiamo ricevuto il tuo pagamento. Purtroppo alcuni degli ar" +
+	"ticoli che hai ordinato sono esauriti. Li consegneremo il prima possibil" +
+	"e e ti avviseremo di nuovo. Puoi già scaricare gli articoli che sono sta" +
+	"ti consegnati.\x02Tutti gli articoli consegnati\x02Gli articoli rimanent" +
+	"i del tuo ordine sono stati consegnati. I tuoi codici sono elencati qui " +
+	"sotto.\x02Gli articoli rimanenti del tuo ordine sono stati consegnati. S" +
+	"carica i tuoi voucher entro i prossimi 30 giorni.\x02Nuovo messaggio sul" +
+	" tuo ordine\x02Ti abbiamo lasciato un messaggio nella pagina del tuo ord" +
+	"ine. Dagli un'occhiata.\x02Formati degli indirizzi"
+
+	// Total table size 47995 bytes (46KiB); checksum: C8370874
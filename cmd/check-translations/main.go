@@ -0,0 +1,91 @@
+// Command check-translations reports messages which are missing or untranslated in locales/*/messages.gotext.json.
+// Run it from the repository root after go generate. It exits with status 1 if anything is incomplete.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+type messages struct {
+	Language string `json:"language"`
+	Messages []struct {
+		ID          string `json:"id"`
+		Translation string `json:"translation"`
+		Fuzzy       bool   `json:"fuzzy"`
+	} `json:"messages"`
+}
+
+func load(path string) (*messages, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m = &messages{}
+	return m, json.Unmarshal(data, m)
+}
+
+func main() {
+	var dir = flag.String("locales", "locales", "locales directory")
+	var src = flag.String("src", "en-US", "source language")
+	var verbose = flag.Bool("v", false, "list the affected message IDs")
+	flag.Parse()
+
+	source, err := load(filepath.Join(*dir, *src, "out.gotext.json"))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(*dir, "*", "messages.gotext.json"))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	slices.Sort(paths)
+
+	var incomplete bool
+	for _, path := range paths {
+		translated, err := load(path)
+		if err != nil {
+			fmt.Printf("%s: %v\n", path, err)
+			os.Exit(2)
+		}
+
+		var have = make(map[string]bool)
+		var untranslated []string
+		for _, msg := range translated.Messages {
+			have[msg.ID] = true
+			if msg.Translation == "" || msg.Fuzzy {
+				untranslated = append(untranslated, msg.ID)
+			}
+		}
+		var missing []string
+		for _, msg := range source.Messages {
+			if !have[msg.ID] {
+				missing = append(missing, msg.ID)
+			}
+		}
+
+		fmt.Printf("%s: %d of %d messages translated, %d untranslated, %d missing\n", translated.Language, len(source.Messages)-len(untranslated)-len(missing), len(source.Messages), len(untranslated), len(missing))
+		if *verbose {
+			for _, id := range untranslated {
+				fmt.Printf("  untranslated: %q\n", id)
+			}
+			for _, id := range missing {
+				fmt.Printf("  missing:      %q\n", id)
+			}
+		}
+		if len(untranslated) > 0 || len(missing) > 0 {
+			incomplete = true
+		}
+	}
+
+	if incomplete {
+		os.Exit(1)
+	}
+}
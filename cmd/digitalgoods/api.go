@@ -0,0 +1,343 @@
+package main
+
+import (
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/eco/countries"
+	"github.com/julienschmidt/httprouter"
+)
+
+// staff JSON API, version 1, see openapi.yaml
+
+//go:embed openapi.yaml
+var openapiYAML []byte
+
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (err apiError) Error() string {
+	return err.Message
+}
+
+func badRequest(format string, a ...any) error {
+	return apiError{http.StatusBadRequest, fmt.Sprintf(format, a...)}
+}
+
+func notFound(format string, a ...any) error {
+	return apiError{http.StatusNotFound, fmt.Sprintf(format, a...)}
+}
+
+// authenticateToken checks the bearer token of the request and returns the token name.
+func (s *Shop) authenticateToken(r *http.Request) (string, error) {
+	if s.APITokens == nil {
+		return "", errors.New("no api tokens configured")
+	}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return s.APITokens.AuthenticateToken(token)
+}
+
+func (s *Shop) api(f func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, err := s.authenticateToken(r)
+		if err != nil {
+			writeAPIError(w, r, apiError{http.StatusUnauthorized, err.Error()})
+			return
+		}
+		if r.Method != http.MethodGet {
+			slog.InfoContext(r.Context(), "api request", "method", r.Method, "path", r.URL.Path, "token_name", name)
+		}
+
+		result, err := f(r)
+		if err != nil {
+			writeAPIError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, r *http.Request, err error) {
+	var aerr apiError
+	switch {
+	case errors.As(err, &aerr):
+		// ok
+	case errors.Is(err, sql.ErrNoRows):
+		aerr = apiError{http.StatusNotFound, "not found"}
+	default:
+		slog.ErrorContext(r.Context(), "api error", "err", err)
+		aerr = apiError{http.StatusInternalServerError, err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(aerr.Status)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{
+		Error: aerr.Message,
+	})
+}
+
+func decodeAPIRequest(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 10<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest("decoding request body: %v", err)
+	}
+	return nil
+}
+
+type apiVariant struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Price     int    `json:"price"` // euro cents
+	WarnStock int    `json:"warn_stock"`
+}
+
+type apiStockUnit struct {
+	StockID        string       `json:"stock_id"`
+	Brand          string       `json:"brand"`
+	Stock          int          `json:"stock"`
+	Underdelivered int          `json:"underdelivered"`
+	Variants       []apiVariant `json:"variants"`
+}
+
+type apiOrderRow struct {
+	VariantID string `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+	ItemPrice int    `json:"item_price"`           // euro cents, after discount
+	ListPrice int    `json:"list_price,omitempty"` // euro cents, before discount
+	Discount  string `json:"discount,omitempty"`   // discount code
+}
+
+type apiDeliveredItem struct {
+	VariantID    string `json:"variant_id"`
+	Payload      string `json:"payload"` // masked
+	DeliveryDate string `json:"delivery_date"`
+}
+
+type apiPurchase struct {
+	ID         string              `json:"id"`
+	Status     digitalgoods.Status `json:"status"`
+	Message    string              `json:"message"`
+	Country    string              `json:"country"`
+	CreateDate string              `json:"create_date"`
+	DeleteDate string              `json:"delete_date"`
+	Sum        int                 `json:"sum"` // euro cents
+	Ordered    []apiOrderRow       `json:"ordered"`
+	Delivered  []apiDeliveredItem  `json:"delivered"`
+}
+
+func makeAPIPurchase(purchase *digitalgoods.Purchase) apiPurchase {
+	var result = apiPurchase{
+		ID:         purchase.ID,
+		Status:     purchase.Status,
+		Message:    purchase.Message,
+		Country:    purchase.CountryCode,
+		CreateDate: purchase.CreateDate,
+		DeleteDate: purchase.DeleteDate,
+		Sum:        purchase.Ordered.Sum(),
+		Ordered:    []apiOrderRow{},
+		Delivered:  []apiDeliveredItem{},
+	}
+	for _, row := range purchase.Ordered {
+		result.Ordered = append(result.Ordered, apiOrderRow{
+			VariantID: row.VariantID,
+			Quantity:  row.Quantity,
+			ItemPrice: row.ItemPrice,
+			ListPrice: row.ListPrice,
+			Discount:  row.Discount,
+		})
+	}
+	for _, item := range purchase.Delivered {
+		result.Delivered = append(result.Delivered, apiDeliveredItem{
+			VariantID:    item.VariantID,
+			Payload:      digitalgoods.Mask(item.Payload),
+			DeliveryDate: item.DeliveryDate,
+		})
+	}
+	return result
+}
+
+type apiSale struct {
+	PayDate     string `json:"pay_date"`
+	ID          string `json:"id"`
+	Country     string `json:"country"`
+	Gross       int    `json:"gross"` // euro cents
+	Difftax     int    `json:"difftax"`
+	VATRate     string `json:"vat_rate"`
+	Description string `json:"description"`
+	IsService   bool   `json:"is_service"`
+}
+
+func (s *Shop) apiOpenAPIGet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(openapiYAML)
+}
+
+func (s *Shop) apiStockGet(r *http.Request) (any, error) {
+	stock, err := s.Database.GetStock()
+	if err != nil {
+		return nil, err
+	}
+	underdelivered, err := s.getUnderdelivered()
+	if err != nil {
+		return nil, err
+	}
+
+	var units = []apiStockUnit{}
+	for _, brand := range s.uploadCatalog {
+		for _, unit := range brand.Units {
+			var variants []apiVariant
+			for _, v := range unit.Variants {
+				variants = append(variants, apiVariant{
+					ID:        v.ID,
+					Name:      v.Name,
+					Price:     v.Price,
+					WarnStock: v.WarnStock,
+				})
+			}
+			units = append(units, apiStockUnit{
+				StockID:        unit.StockID,
+				Brand:          brand.Brand,
+				Stock:          stock[unit.StockID],
+				Underdelivered: underdelivered[unit.StockID],
+				Variants:       variants,
+			})
+		}
+	}
+	return units, nil
+}
+
+func (s *Shop) apiStockPost(r *http.Request) (any, error) {
+	stockID := httprouter.ParamsFromContext(r.Context()).ByName("stockid")
+	if _, ok := s.uploadCatalog.UploadStockUnit(stockID); !ok {
+		return nil, notFound("stock unit %s not found", stockID)
+	}
+
+	var req struct {
+		Codes []string `json:"codes"`
+	}
+	if err := decodeAPIRequest(r, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Codes) == 0 {
+		return nil, badRequest("no codes given")
+	}
+	for i, code := range req.Codes {
+		if code == "" || len(strings.Fields(code)) != 1 || strings.TrimSpace(code) != code {
+			return nil, badRequest("code %d is empty or contains whitespace", i)
+		}
+	}
+
+	if err := s.addToStock(stockID, req.Codes); err != nil {
+		return nil, err
+	}
+
+	stock, err := s.Database.GetStock()
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		StockID string `json:"stock_id"`
+		Added   int    `json:"added"`
+		Stock   int    `json:"stock"`
+	}{
+		StockID: stockID,
+		Added:   len(req.Codes),
+		Stock:   stock[stockID],
+	}, nil
+}
+
+func (s *Shop) apiPurchase(r *http.Request) (*digitalgoods.Purchase, error) {
+	id := strings.ToUpper(strings.TrimSpace(httprouter.ParamsFromContext(r.Context()).ByName("id")))
+	purchase, err := s.Database.GetPurchaseByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFound("purchase %s not found", id)
+	}
+	return purchase, err
+}
+
+func (s *Shop) apiPurchaseGet(r *http.Request) (any, error) {
+	purchase, err := s.apiPurchase(r)
+	if err != nil {
+		return nil, err
+	}
+	return makeAPIPurchase(purchase), nil
+}
+
+func (s *Shop) apiPurchaseMarkPaidPost(r *http.Request) (any, error) {
+	purchase, err := s.apiPurchase(r)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		Country string `json:"country"` // optional
+	}
+	if err := decodeAPIRequest(r, &req); err != nil {
+		return nil, err
+	}
+	if country := countries.Country(req.Country); country != "" && country != countries.NonEU && !country.InEU() {
+		return nil, badRequest("invalid country: %s", req.Country)
+	}
+	if err := s.markPaid(purchase, req.Country); err != nil {
+		return nil, err
+	}
+	return makeAPIPurchase(purchase), nil
+}
+
+func (s *Shop) apiPurchaseMessagePost(r *http.Request) (any, error) {
+	purchase, err := s.apiPurchase(r)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		Message string `json:"message"`
+	}
+	if err := decodeAPIRequest(r, &req); err != nil {
+		return nil, err
+	}
+	if err := s.setMessage(purchase, req.Message); err != nil {
+		return nil, err
+	}
+	purchase, err = s.Database.GetPurchaseByID(purchase.ID)
+	if err != nil {
+		return nil, err
+	}
+	return makeAPIPurchase(purchase), nil
+}
+
+func (s *Shop) apiSalesGet(r *http.Request) (any, error) {
+	minDate := r.URL.Query().Get("from")
+	if _, err := time.Parse(digitalgoods.DateFmt, minDate); err != nil {
+		return nil, badRequest("invalid or missing from date, want yyyy-mm-dd")
+	}
+	sales, err := s.getSales(minDate)
+	if err != nil {
+		return nil, err
+	}
+	var result = []apiSale{}
+	for _, sale := range sales {
+		result = append(result, apiSale{
+			PayDate:     sale.PayDate,
+			ID:          sale.ID,
+			Country:     sale.Country,
+			Gross:       sale.GrossSum,
+			Difftax:     sale.Difftax,
+			VATRate:     sale.VATRate,
+			Description: sale.Name,
+			IsService:   true, // like in staffExportGet
+		})
+	}
+	return result, nil
+}
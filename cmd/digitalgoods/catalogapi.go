@@ -0,0 +1,187 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/dys2p/digitalgoods"
+)
+
+// public JSON API for catalog and stock, without authentication
+
+type publicTier struct {
+	MinQuantity int `json:"min_quantity"`
+	Price       int `json:"price"` // euro cents
+}
+
+type publicVariant struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	ImageLink    string       `json:"image_link,omitempty"`
+	Price        int          `json:"price"` // euro cents
+	Tiers        []publicTier `json:"tiers,omitempty"`
+	Availability string       `json:"availability"`
+	Stock        int          `json:"stock"` // lower bound if stock_bucketed is true
+}
+
+type publicArticle struct {
+	ID        string            `json:"id"`
+	Brand     string            `json:"brand"`
+	Name      string            `json:"name"`
+	ImageLink string            `json:"image_link,omitempty"`
+	About     map[string]string `json:"about"` // key: language prefix
+	Variants  []publicVariant   `json:"variants"`
+}
+
+type publicCategory struct {
+	Name     map[string]string `json:"name"` // key: language prefix
+	Articles []publicArticle   `json:"articles"`
+}
+
+type publicStock struct {
+	VariantID    string `json:"variant_id"`
+	Availability string `json:"availability"`
+	Stock        int    `json:"stock"`
+}
+
+// availability returns the availability string and the exact or bucketed stock of the variant. If the stock is below the smallest bucket, it returns "low stock".
+func (s *Shop) availability(stock digitalgoods.Stock, variant digitalgoods.Variant) (string, int) {
+	quantity := stock[variant.StockID()]
+	if quantity <= 0 {
+		return "out of stock", 0
+	}
+	bucket := digitalgoods.StockBucket(quantity, s.StockBuckets)
+	if bucket == 0 {
+		return "low stock", 0
+	}
+	return "in stock", bucket
+}
+
+func (s *Shop) publicCatalogGet(w http.ResponseWriter, r *http.Request) {
+	stock, err := s.Database.GetStock()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "public api: error getting stock", "err", err)
+		http.Error(w, "error getting stock", http.StatusInternalServerError)
+		return
+	}
+
+	var categories = []publicCategory{}
+	for _, category := range s.Catalog {
+		var pc = publicCategory{
+			Name:     make(map[string]string),
+			Articles: []publicArticle{},
+		}
+		for _, l := range s.Langs {
+			pc.Name[l.Prefix] = string(category.TranslateName(l))
+		}
+		for _, article := range category.Articles {
+			if article.Hide {
+				continue
+			}
+			var pa = publicArticle{
+				ID:        article.ID,
+				Brand:     article.Brand,
+				Name:      article.Name,
+				ImageLink: article.ImageLink,
+				About:     make(map[string]string),
+			}
+			for _, l := range s.Langs {
+				pa.About[l.Prefix] = string(article.TranslateAbout(l))
+			}
+			for _, variant := range article.Variants {
+				availability, quantity := s.availability(stock, variant)
+				var tiers []publicTier
+				for _, tier := range variant.Tiers {
+					tiers = append(tiers, publicTier{
+						MinQuantity: tier.MinQuantity,
+						Price:       tier.Price,
+					})
+				}
+				pa.Variants = append(pa.Variants, publicVariant{
+					ID:           variant.ID,
+					Name:         variant.Name,
+					ImageLink:    variant.ImageLink,
+					Price:        variant.Price,
+					Tiers:        tiers,
+					Availability: availability,
+					Stock:        quantity,
+				})
+			}
+			pc.Articles = append(pc.Articles, pa)
+		}
+		if len(pc.Articles) > 0 {
+			categories = append(categories, pc)
+		}
+	}
+
+	writePublicJSON(w, r, struct {
+		StockBucketed bool             `json:"stock_bucketed"`
+		Categories    []publicCategory `json:"categories"`
+	}{
+		StockBucketed: len(s.StockBuckets) > 0,
+		Categories:    categories,
+	})
+}
+
+func (s *Shop) publicStockGet(w http.ResponseWriter, r *http.Request) {
+	stock, err := s.Database.GetStock()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "public api: error getting stock", "err", err)
+		http.Error(w, "error getting stock", http.StatusInternalServerError)
+		return
+	}
+
+	var variants = []publicStock{}
+	var seen = make(map[string]bool) // variants can occur in multiple articles
+	for article := range s.Catalog.Articles() {
+		if article.Hide {
+			continue
+		}
+		for _, variant := range article.Variants {
+			if seen[variant.ID] {
+				continue
+			}
+			seen[variant.ID] = true
+			availability, quantity := s.availability(stock, variant)
+			variants = append(variants, publicStock{
+				VariantID:    variant.ID,
+				Availability: availability,
+				Stock:        quantity,
+			})
+		}
+	}
+
+	writePublicJSON(w, r, struct {
+		StockBucketed bool          `json:"stock_bucketed"`
+		Variants      []publicStock `json:"variants"`
+	}{
+		StockBucketed: len(s.StockBuckets) > 0,
+		Variants:      variants,
+	})
+}
+
+// writePublicJSON writes v with an ETag and caching headers, and responds with 304 Not Modified if the client has the current version.
+func writePublicJSON(w http.ResponseWriter, r *http.Request, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "public api: error marshaling", "err", err)
+		http.Error(w, "error marshaling response", http.StatusInternalServerError)
+		return
+	}
+	hash := sha256.Sum256(body)
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:16]))
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
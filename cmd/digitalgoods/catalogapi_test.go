@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestStockAPI(t *testing.T) {
+	ts := newTestShop(t)
+	ts.shop.StockBuckets = []int{5, 10}
+
+	var codes []string
+	for i := 0; i < 7; i++ {
+		codes = append(codes, fmt.Sprintf("VOUCHER5-%d", i))
+	}
+	ts.upload("voucher-5", codes...)
+	ts.upload("voucher-10", "VOUCHER10-1", "VOUCHER10-2")
+
+	var resp struct {
+		StockBucketed bool          `json:"stock_bucketed"`
+		Variants      []publicStock `json:"variants"`
+	}
+	if err := json.Unmarshal([]byte(ts.get(ts.custClient, ts.cust.URL+"/api/stock")), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.StockBucketed {
+		t.Error("stock is not reported as bucketed")
+	}
+
+	var want = map[string]publicStock{
+		"voucher-5":  {VariantID: "voucher-5", Availability: "in stock", Stock: 5},
+		"voucher-10": {VariantID: "voucher-10", Availability: "low stock", Stock: 0},
+		"shared-a":   {VariantID: "shared-a", Availability: "out of stock", Stock: 0},
+	}
+	for _, got := range resp.Variants {
+		if w, ok := want[got.VariantID]; ok && got != w {
+			t.Errorf("got %+v, want %+v", got, w)
+		}
+		delete(want, got.VariantID)
+	}
+	for id := range want {
+		t.Errorf("missing variant %s", id)
+	}
+}
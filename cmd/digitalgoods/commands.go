@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/digitalgoods/db"
+	"github.com/dys2p/eco/lang"
+)
+
+// command is a subcommand of digitalgoods. Commands other than serve work on the database and exit. They are safe to run next to the server, because SQLite serializes the write transactions, see db.OpenDB. Notifications are enqueued and delivered by the outbox of the server.
+type command struct {
+	Name  string // one or two words
+	Args  string // for usage
+	Usage string
+	Run   func(s *Shop, out io.Writer, args []string) error // nil for serve
+}
+
+var commands = []command{
+	{"serve", "", "run the customer and staff listeners (default)", nil},
+	{"cleanup", "", "delete expired purchases and the notifications of deleted purchases", cmdCleanup},
+	{"fulfil", "", "deliver underdelivered purchases from stock", cmdFulfil},
+	{"purchase show", "<id>", "print a purchase as JSON, delivered codes are masked", cmdPurchaseShow},
+	{"stock import", "<stock-id> <file>", "add the whitespace-separated codes from the file (- for stdin) to the stock, then deliver underdelivered purchases", cmdStockImport},
+	{"sales export", "<from>", "write the sales since the date (yyyy-mm-dd) as CSV, like the staff export", cmdSalesExport},
+	{"db migrate", "", "create missing tables and columns, which is also done on every start", cmdDBMigrate},
+}
+
+// findCommand returns the command whose name matches the beginning of args, and the remaining args. Without args, it returns the serve command.
+func findCommand(args []string) (command, []string, bool) {
+	if len(args) == 0 {
+		return commands[0], nil, true
+	}
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.Name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == cmd.Name {
+			return cmd, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] [command] [flags] [args]\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %s\n    \t%s\n", strings.TrimSpace(cmd.Name+" "+cmd.Args), cmd.Usage)
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// runCommand runs a command other than serve against the storefront with the given ID. Output is written to out, log messages go to slog.
+func runCommand(cmd command, config Config, database *db.DB, storefrontID string, langs []string, out io.Writer, args []string) error {
+	storefront, ok := config.Storefront(storefrontID)
+	if !ok {
+		return fmt.Errorf("storefront %q not found", storefrontID)
+	}
+	cata, err := storefront.LoadCatalog(catalog)
+	if err != nil {
+		return err
+	}
+	if storefront.Langs != "" {
+		langs = strings.Split(storefront.Langs, ",")
+	}
+
+	// without emailer, payment methods and listeners
+	s := &Shop{
+		BaseURL:  storefront.BaseURL,
+		Database: database.Shop(storefront.ID),
+		ID:       storefront.ID,
+		Langs:    lang.MakeLanguages(nil, langs...),
+		VATRate:  storefront.VAT.Rate,
+	}
+	s.SetCatalog(cata)
+	return cmd.Run(s, out, args)
+}
+
+// wantArgs returns an error unless args has length n.
+func wantArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("got %d arguments, want %d", len(args), n)
+	}
+	return nil
+}
+
+func cmdCleanup(s *Shop, out io.Writer, args []string) error {
+	if err := wantArgs(args, 0); err != nil {
+		return err
+	}
+	return s.Database.Cleanup()
+}
+
+func cmdFulfil(s *Shop, out io.Writer, args []string) error {
+	if err := wantArgs(args, 0); err != nil {
+		return err
+	}
+	underdelivered, err := s.Database.GetPurchases(digitalgoods.StatusUnderdelivered)
+	if err != nil {
+		return err
+	}
+	if err := s.Database.FulfilUnderdelivered(s.Catalog); err != nil {
+		return err
+	}
+	remaining, err := s.Database.GetPurchases(digitalgoods.StatusUnderdelivered)
+	if err != nil {
+		return err
+	}
+	slog.Info("fulfilled underdelivered purchases", "before", len(underdelivered), "remaining", len(remaining))
+	return nil
+}
+
+func cmdPurchaseShow(s *Shop, out io.Writer, args []string) error {
+	if err := wantArgs(args, 1); err != nil {
+		return err
+	}
+	purchase, err := s.Database.GetPurchaseByID(strings.ToUpper(strings.TrimSpace(args[0])))
+	if err != nil {
+		return fmt.Errorf("getting purchase: %w", err)
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(makeAPIPurchase(purchase))
+}
+
+func cmdStockImport(s *Shop, out io.Writer, args []string) error {
+	if err := wantArgs(args, 2); err != nil {
+		return err
+	}
+	stockID := args[0]
+	if _, ok := s.uploadCatalog.UploadStockUnit(stockID); !ok {
+		return errors.New("stock unit not found")
+	}
+
+	var in io.Reader = os.Stdin
+	if args[1] != "-" {
+		file, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	codes := strings.Fields(string(data))
+	if len(codes) == 0 {
+		return errors.New("no codes found")
+	}
+	if err := s.addToStock(stockID, codes); err != nil {
+		return err
+	}
+	slog.Info("imported codes", "stock", stockID, "count", len(codes))
+	return nil
+}
+
+func cmdSalesExport(s *Shop, out io.Writer, args []string) error {
+	if err := wantArgs(args, 1); err != nil {
+		return err
+	}
+	if _, err := time.Parse(digitalgoods.DateFmt, args[0]); err != nil {
+		return errors.New("invalid from date, want yyyy-mm-dd")
+	}
+	sales, err := s.getSales(args[0])
+	if err != nil {
+		return err
+	}
+	return writeSales(out, sales)
+}
+
+// cmdDBMigrate does nothing because db.OpenDB has migrated the database already.
+func cmdDBMigrate(s *Shop, out io.Writer, args []string) error {
+	if err := wantArgs(args, 0); err != nil {
+		return err
+	}
+	slog.Info("database is up to date")
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dys2p/digitalgoods"
+)
+
+func TestFindCommand(t *testing.T) {
+	for _, tc := range []struct {
+		args     []string
+		wantName string
+		wantArgs []string
+	}{
+		{nil, "serve", nil},
+		{[]string{"serve"}, "serve", nil},
+		{[]string{"purchase", "show", "ABC123"}, "purchase show", []string{"ABC123"}},
+		{[]string{"stock", "import", "voucher-5", "-"}, "stock import", []string{"voucher-5", "-"}},
+		{[]string{"purchase"}, "", nil},
+		{[]string{"unknown"}, "", nil},
+	} {
+		cmd, args, ok := findCommand(tc.args)
+		if ok != (tc.wantName != "") || cmd.Name != tc.wantName || strings.Join(args, " ") != strings.Join(tc.wantArgs, " ") {
+			t.Errorf("findCommand(%v): got %q %v %t, want %q %v", tc.args, cmd.Name, args, ok, tc.wantName, tc.wantArgs)
+		}
+	}
+}
+
+func TestCmdStockImport(t *testing.T) {
+	ts := newTestShop(t)
+
+	// one code is delivered to the underdelivered purchase, the other one is added to the stock
+	purchase := ts.order(map[string]int{"voucher-voucher-5": 1})
+	ts.markPaid(purchase)
+	ts.reload(purchase, digitalgoods.StatusUnderdelivered)
+
+	codesPath := filepath.Join(t.TempDir(), "codes.txt")
+	if err := os.WriteFile(codesPath, []byte("CMD-1\n  CMD-2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmdStockImport(ts.shop, io.Discard, []string{"voucher-5", codesPath}); err != nil {
+		t.Fatal(err)
+	}
+	ts.reload(purchase, digitalgoods.StatusFinalized)
+	if stock := ts.stock(); stock["voucher-5"] != 1 {
+		t.Fatalf("got stock %d, want 1", stock["voucher-5"])
+	}
+
+	if err := cmdStockImport(ts.shop, io.Discard, []string{"unknown", codesPath}); err == nil {
+		t.Error("stock import into unknown stock unit has been accepted")
+	}
+	if err := os.WriteFile(codesPath, []byte(" \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmdStockImport(ts.shop, io.Discard, []string{"voucher-5", codesPath}); err == nil {
+		t.Error("stock import without codes has been accepted")
+	}
+}
+
+func TestCmdFulfil(t *testing.T) {
+	ts := newTestShop(t)
+	purchase := ts.order(map[string]int{"voucher-voucher-5": 1})
+	ts.markPaid(purchase)
+	if err := ts.shop.Database.AddToStock("voucher-5", "fulfil-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmdFulfil(ts.shop, io.Discard, nil); err != nil {
+		t.Fatal(err)
+	}
+	ts.reload(purchase, digitalgoods.StatusFinalized)
+
+	if err := cmdCleanup(ts.shop, io.Discard, []string{"now"}); err == nil {
+		t.Error("cleanup with arguments has been accepted")
+	}
+	if err := cmdDBMigrate(ts.shop, io.Discard, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCmdPurchaseShow(t *testing.T) {
+	ts := newTestShop(t)
+	purchase := ts.order(map[string]int{"voucher-voucher-5": 1})
+
+	var out bytes.Buffer
+	if err := cmdPurchaseShow(ts.shop, &out, []string{strings.ToLower(purchase.ID)}); err != nil {
+		t.Fatal(err)
+	}
+	var shown apiPurchase
+	if err := json.Unmarshal(out.Bytes(), &shown); err != nil {
+		t.Fatal(err)
+	}
+	if shown.ID != purchase.ID || shown.Status != digitalgoods.StatusNew {
+		t.Fatalf("got %s %s, want %s new", shown.ID, shown.Status, purchase.ID)
+	}
+	if err := cmdPurchaseShow(ts.shop, io.Discard, []string{"XXXXXX"}); err == nil {
+		t.Error("purchase show of unknown purchase has succeeded")
+	}
+}
+
+func TestCmdSalesExport(t *testing.T) {
+	ts := newTestShop(t)
+	ts.upload("voucher-5", "sales-1")
+	ts.markPaid(ts.order(map[string]int{"voucher-voucher-5": 1}))
+
+	var out bytes.Buffer
+	if err := cmdSalesExport(ts.shop, &out, []string{"2000-01-01"}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || lines[0] != "pay_date,id,country,gross,difftax,vat_rate,description,is_service" || !strings.Contains(lines[1], ",500,0,standard,voucher-5,") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	if err := cmdSalesExport(ts.shop, io.Discard, []string{"yesterday"}); err == nil {
+		t.Error("sales export with invalid date has been accepted")
+	}
+}
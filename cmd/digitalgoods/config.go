@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+)
+
+// Config is the runtime configuration of the process. It is read from config.json in the configuration directory. Omitted values are set to the defaults of DefaultConfig.
+//
+// The embedded StorefrontConfig configures the default storefront. Additional storefronts share the listeners, the database and the staff users and API tokens.
+type Config struct {
+	StorefrontConfig
+
+	CustomerAddr string             `json:"customer-addr"` // listen address of the customer listener, which is shared by all storefronts
+	BuyRatesURL  string             `json:"buy-rates-url"` // optional, returns a JSON object which maps currency codes to units per euro, enables payment by cash in foreign currencies
+	Storefronts  []StorefrontConfig `json:"storefronts"`   // optional, additional storefronts
+}
+
+// StorefrontConfig configures a storefront. Customer requests are dispatched to the storefront by the host name of its base URL or one of its additional hosts. Requests with an unknown host name are served by the default storefront.
+type StorefrontConfig struct {
+	ID          string   `json:"id"`           // scope of purchases, stock and sales in the database, empty for the default storefront
+	BaseURL     string   `json:"base-url"`     // public URL of the storefront, used in links and in the product feed
+	Hosts       []string `json:"hosts"`        // optional, additional host names, e.g. an onion service
+	StaffAddr   string   `json:"staff-addr"`   // listen address of the staff listener, should not be public
+	EmailFrom   string   `json:"email-from"`   // sender address of emails, also receives service messages
+	NtfyshTopic string   `json:"ntfysh-topic"` // optional, ntfy.sh topic for service messages and errors
+	Title       string   `json:"title"`        // optional, title of the customer pages and the product feed, defaults to the host name of the base URL
+
+	Catalog string `json:"catalog"`  // optional, JSON file with the catalog, relative to the configuration directory, defaults to the built-in catalog
+	Langs   string `json:"langs"`    // optional, comma-separated language prefixes, the first one is the default, defaults to the -langs flag
+	SiteDir string `json:"site-dir"` // optional, site directory with static pages and layout templates, relative to the configuration directory, defaults to the built-in site
+	Btcpay  string `json:"btcpay"`   // optional, BTCPay Server config file, relative to the configuration directory, defaults to btcpay.json
+
+	StockBuckets []int `json:"stock-buckets"` // optional, positive and in ascending order, the public API reports stock in buckets instead of exact counts
+
+	CashAddressHTML string          `json:"cash-address-html"` // optional, postal address for cash payments, enables payment by cash
+	SEPAAccount     json.RawMessage `json:"sepa-account"`      // optional, bank account in the format of payment.SEPA.Account, enables payment by SEPA bank transfer
+
+	VAT VATRules `json:"vat"`
+}
+
+// DefaultConfig returns the default configuration. It runs a shop on the local host.
+func DefaultConfig() Config {
+	return Config{
+		StorefrontConfig: StorefrontConfig{
+			BaseURL:   "http://localhost:9002",
+			StaffAddr: "127.0.0.1:9003",
+			EmailFrom: "digitalgoods@localhost",
+			Title:     "Digital Goods by ProxyStore",
+			VAT:       VATRules{{Rate: "standard"}},
+		},
+		CustomerAddr: ":9002",
+	}
+}
+
+// LoadConfig reads a Config from a JSON file and validates it. If the file does not exist, it returns the DefaultConfig.
+func LoadConfig(path string) (Config, error) {
+	config := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields() // catch typos
+	if err := decoder.Decode(&config); err != nil {
+		return config, err
+	}
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	for i := range config.Storefronts {
+		config.Storefronts[i].BaseURL = strings.TrimSuffix(config.Storefronts[i].BaseURL, "/")
+	}
+	return config, config.Validate()
+}
+
+// Validate returns the first invalid value.
+func (config Config) Validate() error {
+	if _, _, err := net.SplitHostPort(config.CustomerAddr); err != nil {
+		return fmt.Errorf("customer-addr: %w", err)
+	}
+	if config.BuyRatesURL != "" {
+		if u, err := url.Parse(config.BuyRatesURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("buy-rates-url: must be an http or https URL, got %q", config.BuyRatesURL)
+		}
+	}
+	if config.ID != "" {
+		return errors.New("id: must be empty for the default storefront")
+	}
+
+	var ids = make(map[string]bool)
+	var hosts = make(map[string]bool)
+	var staffAddrs = make(map[string]bool)
+	for i, storefront := range config.All() {
+		if err := storefront.Validate(); err != nil {
+			if i == 0 {
+				return err
+			}
+			return fmt.Errorf("storefronts: %d: %w", i, err)
+		}
+		if i > 0 && storefront.ID == "" {
+			return fmt.Errorf("storefronts: %d: missing id", i)
+		}
+		if ids[storefront.ID] {
+			return fmt.Errorf("storefronts: duplicate id %q", storefront.ID)
+		}
+		ids[storefront.ID] = true
+		for _, host := range storefront.AllHosts() {
+			if hosts[host] {
+				return fmt.Errorf("storefronts: duplicate host %q", host)
+			}
+			hosts[host] = true
+		}
+		if staffAddrs[storefront.StaffAddr] {
+			return fmt.Errorf("storefronts: duplicate staff-addr %q", storefront.StaffAddr)
+		}
+		staffAddrs[storefront.StaffAddr] = true
+	}
+	return nil
+}
+
+// All returns the default storefront and the additional storefronts.
+func (config Config) All() []StorefrontConfig {
+	return append([]StorefrontConfig{config.StorefrontConfig}, config.Storefronts...)
+}
+
+// Storefront returns the storefront with the given ID. The default storefront has the empty ID.
+func (config Config) Storefront(id string) (StorefrontConfig, bool) {
+	for _, storefront := range config.All() {
+		if storefront.ID == id {
+			return storefront, true
+		}
+	}
+	return StorefrontConfig{}, false
+}
+
+// Validate returns the first invalid value.
+func (storefront StorefrontConfig) Validate() error {
+	if storefront.ID != "" && !validStorefrontID.MatchString(storefront.ID) {
+		return fmt.Errorf("id: must consist of lowercase letters, digits and dashes, got %q", storefront.ID)
+	}
+	if u, err := url.Parse(storefront.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+		return fmt.Errorf("base-url: must be an http or https URL without path, got %q", storefront.BaseURL)
+	}
+	if _, _, err := net.SplitHostPort(storefront.StaffAddr); err != nil {
+		return fmt.Errorf("staff-addr: %w", err)
+	}
+	if _, err := mail.ParseAddress(storefront.EmailFrom); err != nil {
+		return fmt.Errorf("email-from: %w", err)
+	}
+	if storefront.SEPAAccount != nil && !json.Valid(storefront.SEPAAccount) {
+		return errors.New("sepa-account: invalid json")
+	}
+	for i, bucket := range storefront.StockBuckets {
+		if bucket <= 0 || (i > 0 && bucket <= storefront.StockBuckets[i-1]) {
+			return fmt.Errorf("stock-buckets: must be positive and in ascending order, got %v", storefront.StockBuckets)
+		}
+	}
+	if err := storefront.VAT.Validate(); err != nil {
+		return fmt.Errorf("vat: %w", err)
+	}
+	return nil
+}
+
+// LoadCatalog returns the catalog of the storefront, or the builtin catalog if none is configured.
+func (storefront StorefrontConfig) LoadCatalog(builtin digitalgoods.Catalog) (digitalgoods.Catalog, error) {
+	if storefront.Catalog == "" {
+		return builtin, nil
+	}
+	catalog, err := digitalgoods.LoadCatalog(configPath(storefront.Catalog))
+	if err != nil {
+		return nil, fmt.Errorf("loading catalog: %w", err)
+	}
+	return catalog, nil
+}
+
+// AllHosts returns the host of the base URL and the additional hosts, in lower case and without port.
+func (storefront StorefrontConfig) AllHosts() []string {
+	u, _ := url.Parse(storefront.BaseURL) // validated
+	var hosts = []string{u.Hostname()}
+	for _, host := range storefront.Hosts {
+		hosts = append(hosts, stripPort(host))
+	}
+	for i := range hosts {
+		hosts[i] = strings.ToLower(hosts[i])
+	}
+	return hosts
+}
+
+// stripPort returns the host without port. Unlike net.SplitHostPort, it accepts a host without port.
+func stripPort(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return hostport
+}
+
+var validStorefrontID = regexp.MustCompile(`^[a-z0-9\-]{1,32}$`)
+
+// LocalCustomerURL returns the URL of the customer listener on the local host, for the BTCPay Server stand-in in test mode.
+func (config Config) LocalCustomerURL() string {
+	host, port, _ := net.SplitHostPort(config.CustomerAddr) // validated
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// GetBuyRates fetches the buy rates for payment by cash in foreign currencies.
+func (config Config) GetBuyRates() (map[string]float64, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(config.BuyRatesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("buy rates: got status %d", resp.StatusCode)
+	}
+	var rates map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&rates); err != nil {
+		return nil, fmt.Errorf("buy rates: %w", err)
+	}
+	return rates, nil
+}
+
+// VATRule assigns a VAT rate to the sales which match all of its conditions.
+type VATRule struct {
+	Countries []string `json:"countries"` // optional, country codes of the customer, empty matches all countries
+	Service   *bool    `json:"service"`   // optional, matches Sale.IsService
+	Rate      string   `json:"rate"`      // name of the VAT rate in the sales export, e.g. "standard" or "reduced"
+	Difftax   int      `json:"difftax"`   // written to the sales export as is
+}
+
+func (rule VATRule) matches(sale digitalgoods.Sale) bool {
+	if len(rule.Countries) > 0 && !slices.Contains(rule.Countries, sale.Country) {
+		return false
+	}
+	if rule.Service != nil && *rule.Service != sale.IsService {
+		return false
+	}
+	return true
+}
+
+// VATRules are checked in order. The first matching rule applies. The last rule must match all sales.
+type VATRules []VATRule
+
+func (rules VATRules) Validate() error {
+	if len(rules) == 0 {
+		return errors.New("no rules")
+	}
+	for i, rule := range rules {
+		if rule.Rate == "" {
+			return fmt.Errorf("rule %d: missing rate", i+1)
+		}
+	}
+	if last := rules[len(rules)-1]; len(last.Countries) > 0 || last.Service != nil {
+		return errors.New("the last rule must not have conditions")
+	}
+	return nil
+}
+
+// Rate returns the VAT rate and the difftax value of the first matching rule. It can be used as Shop.VATRate.
+func (rules VATRules) Rate(sale digitalgoods.Sale) (string, int) {
+	for _, rule := range rules {
+		if rule.matches(sale) {
+			return rule.Rate, rule.Difftax
+		}
+	}
+	return "", 0 // not reached if rules are valid
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dys2p/digitalgoods"
+)
+
+// writeConfig writes data to a config file in a temporary directory and returns its path.
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	// defaults
+	config, err := LoadConfig(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("missing config file: %v", err)
+	}
+	if config.CustomerAddr != ":9002" || config.StaffAddr != "127.0.0.1:9003" || config.LocalCustomerURL() != "http://127.0.0.1:9002" {
+		t.Fatalf("unexpected default config: %+v", config)
+	}
+
+	// valid file, omitted values keep their defaults
+	config, err = LoadConfig(writeConfig(t, `{
+		"base-url": "https://shop.example.com/",
+		"sepa-account": {"holder": "Example"},
+		"vat": [
+			{"countries": ["CH"], "rate": "non-eu"},
+			{"service": true, "rate": "service", "difftax": 1},
+			{"rate": "standard"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.BaseURL != "https://shop.example.com" || config.EmailFrom != "digitalgoods@localhost" {
+		t.Fatalf("unexpected config: %+v", config)
+	}
+	for _, tc := range []struct {
+		sale        digitalgoods.Sale
+		wantRate    string
+		wantDifftax int
+	}{
+		{digitalgoods.Sale{Country: "CH", IsService: true}, "non-eu", 0},
+		{digitalgoods.Sale{Country: "DE", IsService: true}, "service", 1},
+		{digitalgoods.Sale{Country: "DE"}, "standard", 0},
+	} {
+		if rate, difftax := config.VAT.Rate(tc.sale); rate != tc.wantRate || difftax != tc.wantDifftax {
+			t.Errorf("vat of %+v: got %s %d, want %s %d", tc.sale, rate, difftax, tc.wantRate, tc.wantDifftax)
+		}
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{"base-url": "shop.example.com"}`,
+		`{"customer-addr": "9002"}`,
+		`{"email-from": "nobody"}`,
+		`{"buy-rates-url": "ftp://example.com"}`,
+		`{"vat": []}`,
+		`{"vat": [{"rate": "standard"}, {"countries": ["CH"], "rate": "non-eu"}]}`,
+		`{"stock-buckets": [0, 10]}`,
+		`{"stock-buckets": [50, 10]}`,
+		`{"unknown-key": true}`,
+	} {
+		if _, err := LoadConfig(writeConfig(t, data)); err == nil {
+			t.Errorf("invalid config has been accepted: %s", data)
+		}
+	}
+}
+
+func TestLoadConfigStorefronts(t *testing.T) {
+	config, err := LoadConfig(writeConfig(t, `{
+		"base-url": "https://shop.example.com",
+		"storefronts": [
+			{
+				"id": "other",
+				"base-url": "https://Other.example.com/",
+				"hosts": ["other.onion"],
+				"staff-addr": "127.0.0.1:9005",
+				"email-from": "other@example.com",
+				"vat": [{"rate": "standard"}]
+			}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := config.All()
+	if len(all) != 2 || all[0].ID != "" || all[1].ID != "other" {
+		t.Fatalf("unexpected storefronts: %+v", all)
+	}
+	if hosts := all[1].AllHosts(); strings.Join(hosts, " ") != "other.example.com other.onion" {
+		t.Fatalf("unexpected hosts: %v", hosts)
+	}
+
+	const other = `"id": "other", "base-url": "https://other.example.com", "staff-addr": "127.0.0.1:9005", "email-from": "other@example.com", "vat": [{"rate": "standard"}]`
+	for _, data := range []string{
+		`{"id": "main"}`,
+		`{"storefronts": [{"base-url": "https://other.example.com", "staff-addr": "127.0.0.1:9005", "email-from": "other@example.com", "vat": [{"rate": "standard"}]}]}`,
+		`{"storefronts": [{` + other + `, "id": "Other!"}]}`,
+		`{"storefronts": [{` + other + `}, {` + other + `}]}`,
+		`{"storefronts": [{` + other + `, "hosts": ["localhost"]}]}`,
+		`{"storefronts": [{` + other + `, "staff-addr": "127.0.0.1:9003"}]}`,
+		`{"storefronts": [{` + other + `, "customer-addr": ":9004"}]}`,
+	} {
+		if _, err := LoadConfig(writeConfig(t, data)); err == nil {
+			t.Errorf("invalid config has been accepted: %s", data)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	stdhtml "html"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/digitalgoods/pdf"
+	"github.com/dys2p/eco/lang"
+	"github.com/skip2/go-qrcode"
+)
+
+// customer-side export of delivered codes
+
+var exportFormats = map[string]string{
+	"csv": "text/csv; charset=utf-8",
+	"pdf": "application/pdf",
+	"txt": "text/plain; charset=utf-8",
+}
+
+type exportItem struct {
+	Product      string
+	Code         string // formatted with Variant.Fmt
+	DeliveryDate string
+}
+
+var tagRegexp = regexp.MustCompile("<[^>]*>")
+
+// plainName removes HTML tags, entities and soft hyphens from a variant name.
+func plainName(name string) string {
+	name = tagRegexp.ReplaceAllString(name, "")
+	name = stdhtml.UnescapeString(name)
+	return strings.ReplaceAll(name, "\u00ad", "")
+}
+
+func exportItems(articles []digitalgoods.PurchaseArticle) []exportItem {
+	var items []exportItem
+	for _, article := range articles {
+		for _, variant := range article.Variants {
+			for _, item := range variant.Delivered {
+				items = append(items, exportItem{
+					Product:      plainName(variant.Name),
+					Code:         item.Payload, // MakePurchaseArticles has applied Variant.Fmt
+					DeliveryDate: item.DeliveryDate,
+				})
+			}
+		}
+	}
+	return items
+}
+
+// writeText writes the items as plain text, grouped by product
+func writeText(w io.Writer, title string, items []exportItem) {
+	fmt.Fprintf(w, "%s\n", title)
+	var product string
+	for _, item := range items {
+		if item.Product != product {
+			fmt.Fprintf(w, "\n%s\n", item.Product)
+			product = item.Product
+		}
+		fmt.Fprintf(w, "%s\n", item.Code)
+	}
+}
+
+// custExport writes the delivered codes of the purchase as a download. Format must be a key of exportFormats.
+func (s *Shop) custExport(w http.ResponseWriter, l lang.Lang, purchase *digitalgoods.Purchase, format string) http.Handler {
+	contentType, ok := exportFormats[format]
+	if !ok {
+		return s.frontendNotFound(l.Tr("There is no such purchase, or it has been deleted, or the URL is incorrect."))
+	}
+	items := exportItems(digitalgoods.MakePurchaseArticles(s.purchaseCatalog, purchase))
+	title := l.Tr("Order") + " " + purchase.ID
+
+	var buf bytes.Buffer
+	switch format {
+	case "csv":
+		out := csv.NewWriter(&buf)
+		out.Write([]string{l.Tr("Product"), l.Tr("Code"), l.Tr("Delivery date")})
+		for _, item := range items {
+			out.Write([]string{item.Product, item.Code, item.DeliveryDate})
+		}
+		out.Flush()
+	case "pdf":
+		var lines = []pdf.Line{{Text: title, Bold: true}, {}}
+		var product string
+		for _, item := range items {
+			if item.Product != product {
+				if product != "" {
+					lines = append(lines, pdf.Line{})
+				}
+				lines = append(lines, pdf.Line{Text: item.Product, Bold: true})
+				product = item.Product
+			}
+			lines = append(lines, pdf.Line{Text: item.Code})
+		}
+		buf.Write(pdf.Write(title, lines))
+	case "txt":
+		writeText(&buf, title, items)
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="order-%s.%s"`, purchase.ID, format))
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes())
+	return nil
+}
+
+// custQRCode writes the QR code of a delivered item as PNG. Name is "<variant id>-<index>.png", where index counts the delivered items of the variant. The variant must have QRCode set.
+func (s *Shop) custQRCode(w http.ResponseWriter, l lang.Lang, purchase *digitalgoods.Purchase, name string) http.Handler {
+	name, ok := strings.CutSuffix(name, ".png")
+	sep := strings.LastIndex(name, "-")
+	if !ok || sep < 0 {
+		return s.frontendNotFound(l.Tr("There is no such purchase, or it has been deleted, or the URL is incorrect."))
+	}
+	variantID := name[:sep]
+	index, err := strconv.Atoi(name[sep+1:])
+	if err != nil {
+		return s.frontendNotFound(l.Tr("There is no such purchase, or it has been deleted, or the URL is incorrect."))
+	}
+
+	for _, article := range digitalgoods.MakePurchaseArticles(s.purchaseCatalog, purchase) {
+		for _, variant := range article.Variants {
+			if variant.ID != variantID || !variant.QRCode || index < 0 || index >= len(variant.Delivered) {
+				continue
+			}
+			png, err := qrcode.Encode(variant.Delivered[index].Payload, qrcode.Medium, 256) // MakePurchaseArticles has applied Variant.Fmt
+			if err != nil {
+				return s.frontendErr(fmt.Errorf("encoding qr code: %w", err), l.Tr("Error displaying website. Please try again later.")) // content too long
+			}
+			w.Header().Set("Cache-Control", "private, no-store")
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(png)
+			return nil
+		}
+	}
+	return s.frontendNotFound(l.Tr("There is no such purchase, or it has been deleted, or the URL is incorrect."))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/digitalgoods/db"
+)
+
+// healthCheck is a named check which returns nil if everything is fine.
+type healthCheck struct {
+	Name  string
+	Check func() error
+}
+
+// healthResult is the JSON response of /healthz and /readyz.
+type healthResult struct {
+	Status string            `json:"status"` // "ok" or "fail"
+	Checks map[string]string `json:"checks"` // check name => "ok" or error message
+}
+
+// liveChecks verify that the shop can write its databases. If they fail, the process should be restarted or an operator is required.
+func (s *Shop) liveChecks() []healthCheck {
+	checks := []healthCheck{
+		{"database", s.Database.CheckWritable},
+	}
+	if s.SessionsDB != nil {
+		checks = append(checks, healthCheck{"sessions-database", func() error { return db.CheckWritable(s.SessionsDB) }})
+	}
+	if s.RatesDB != nil {
+		checks = append(checks, healthCheck{"rates-database", func() error { return db.CheckWritable(s.RatesDB) }})
+	}
+	return checks
+}
+
+// readyChecks contain the liveChecks and additionally verify the services which are required for taking orders.
+func (s *Shop) readyChecks() []healthCheck {
+	checks := s.liveChecks()
+	if s.RatesHistory != nil {
+		checks = append(checks, healthCheck{"rates", s.checkRates})
+	}
+	if s.BtcpayStatus != nil {
+		checks = append(checks, healthCheck{"btcpay", s.checkBtcpay})
+	}
+	return checks
+}
+
+// checkRates returns an error if the rates daemon has no rates for today.
+func (s *Shop) checkRates() error {
+	options, err := s.RatesHistory.Options(time.Now().Format(digitalgoods.DateFmt), 1.0)
+	if err != nil {
+		return err
+	}
+	for _, option := range options {
+		if option.Currency != "EUR" {
+			return nil
+		}
+	}
+	return errors.New("no rates for today")
+}
+
+// checkBtcpay returns an error if the BTCPay status daemon has not reported yet, or if a payment method is not synced.
+func (s *Shop) checkBtcpay() error {
+	status := s.BtcpayStatus()
+	if len(status) == 0 {
+		return errors.New("no status available")
+	}
+	var unsynced []string
+	for _, item := range status {
+		if !item.Synced {
+			unsynced = append(unsynced, item.Name)
+		}
+	}
+	if len(unsynced) > 0 {
+		return fmt.Errorf("not synced: %s", strings.Join(unsynced, ", "))
+	}
+	return nil
+}
+
+func (s *Shop) healthzGet(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, s.liveChecks())
+}
+
+func (s *Shop) readyzGet(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, s.readyChecks())
+}
+
+// writeHealth runs the checks and responds with status 200 if all of them succeed, else with status 503.
+func writeHealth(w http.ResponseWriter, checks []healthCheck) {
+	var result = healthResult{
+		Status: "ok",
+		Checks: make(map[string]string),
+	}
+	for _, check := range checks {
+		if err := check.Check(); err != nil {
+			result.Status = "fail"
+			result.Checks[check.Name] = err.Error()
+		} else {
+			result.Checks[check.Name] = "ok"
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	if result.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(result)
+}
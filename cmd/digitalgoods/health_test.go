@@ -0,0 +1,90 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dys2p/go-btcpay"
+)
+
+func (ts *testShop) health(path string) (healthResult, int) {
+	ts.t.Helper()
+	var result healthResult
+	resp, err := http.Get(ts.staff.URL + path)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		ts.t.Fatalf("decoding %s: %v", path, err)
+	}
+	return result, resp.StatusCode
+}
+
+func TestHealth(t *testing.T) {
+	ts := newTestShop(t)
+
+	result, status := ts.health("/healthz")
+	if status != http.StatusOK || result.Status != "ok" || result.Checks["database"] != "ok" {
+		t.Fatalf("healthz: got status %d and %+v", status, result)
+	}
+
+	// readiness, the btcpay status daemon might not have reported yet
+	for i := 0; ; i++ {
+		result, status = ts.health("/readyz")
+		if status == http.StatusOK && result.Checks["btcpay"] == "ok" {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("readyz: got status %d and %+v", status, result)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// not ready if btcpay is not synced
+	ts.shop.BtcpayStatus = func() []btcpay.StatusItem {
+		return []btcpay.StatusItem{{Name: "BTC", Synced: false}}
+	}
+	result, status = ts.health("/readyz")
+	if status != http.StatusServiceUnavailable || result.Status != "fail" || result.Checks["btcpay"] != "not synced: BTC" || result.Checks["database"] != "ok" {
+		t.Fatalf("readyz with unsynced btcpay: got status %d and %+v", status, result)
+	}
+}
+
+// TestHealthRatesDatabase checks that /healthz fails while another connection holds the write lock of the rates database.
+func TestHealthRatesDatabase(t *testing.T) {
+	ts := newTestShop(t)
+
+	path := filepath.Join(t.TempDir(), "rates.sqlite3")
+	ratesDB, err := sql.Open("sqlite3", path+"?_busy_timeout=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ratesDB.Close()
+	ts.shop.RatesDB = ratesDB
+
+	result, status := ts.health("/healthz")
+	if status != http.StatusOK || result.Checks["rates-database"] != "ok" {
+		t.Fatalf("healthz: got status %d and %+v", status, result)
+	}
+
+	writer, err := sql.Open("sqlite3", path+"?_txlock=immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	tx, err := writer.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	result, status = ts.health("/healthz")
+	if status != http.StatusServiceUnavailable || result.Checks["rates-database"] == "ok" || result.Checks["database"] != "ok" {
+		t.Fatalf("healthz with locked rates database: got status %d and %+v", status, result)
+	}
+}
@@ -1,24 +1,25 @@
 package main
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/csv"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"io/fs"
-	"log"
+	"log/slog"
+	"maps"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
-	"os/signal"
 	"path"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
-	"sync"
-	"syscall"
 	"time"
 
 	"github.com/alexedwards/scs/sqlite3store"
@@ -27,6 +28,9 @@ import (
 	"github.com/dys2p/digitalgoods"
 	"github.com/dys2p/digitalgoods/db"
 	"github.com/dys2p/digitalgoods/html"
+	"github.com/dys2p/digitalgoods/logging"
+	"github.com/dys2p/digitalgoods/notify"
+	"github.com/dys2p/digitalgoods/notifytest"
 	"github.com/dys2p/digitalgoods/userdb"
 	"github.com/dys2p/eco/countries"
 	"github.com/dys2p/eco/countries/detect"
@@ -45,79 +49,168 @@ import (
 )
 
 type Shop struct {
+	APITokens        userdb.TokenAuthenticator
+	BaseURL          string // without trailing slash
 	Btcpay           btcpay.Store
+	BtcpayStatus     func() []btcpay.StatusItem // optional, see readyChecks
+	Catalog          digitalgoods.Catalog
 	CustomerSessions *scs.SessionManager
 	Database         *db.DB
+	Discounts        digitalgoods.Discounts
+	EmailFrom        string // sender address, also receives service messages
 	Emailer          email.Emailer
+	Hosts            []string // host names of the storefront, see hostMux
+	ID               string   // storefront ID, see db.DB.Shop
 	Langs            lang.Languages
+	LimitToStock     bool // customers can't order more than in stock
+	MaxQuantity      int  // items per purchase, zero means unlimited
+	Notifiers        map[string]notify.Notifier
+	NtfyshTopic      string // optional, receives service messages and errors
 	PaymentMethods   []payment.Method
-	ProductFeed      productfeed.Feed
+	RatesDB          *sql.DB // optional, see liveChecks
 	RatesHistory     *rates.History
+	SessionsDB       *sql.DB // optional, see liveChecks
+	Site             *html.Site
+	StaffAddr        string // listen address
 	StaffSessions    *scs.SessionManager
 	StaffUsers       userdb.Authenticator
+	StockBuckets     []int  // optional, public api reports stock in buckets instead of exact counts, see digitalgoods.StockBucket
+	Title            string // title of the customer pages and the product feed
 	VATRate          func(digitalgoods.Sale) (vatRate string, difftax int)
-}
 
-var CatalogUpdated string // go build -ldflags "-X main.CatalogUpdated=$(date --iso-8601=seconds --utc -r path/to/product-catalog.go)"
+	// derived from Catalog, see SetCatalog
+	brandCatalogs   map[string]digitalgoods.BrandCatalog
+	purchaseCatalog []digitalgoods.Article
+	uploadCatalog   digitalgoods.UploadCatalog
 
-var (
-	bcatalogs map[string]digitalgoods.BrandCatalog = digitalgoods.MakeBrandCatalogs(catalog)
-	pcatalog  []digitalgoods.Article               = digitalgoods.MakePurchaseCatalog(catalog)
-	ucatalog  digitalgoods.UploadCatalog           = digitalgoods.MakeUploadCatalog(catalog)
-)
+	metrics    *shopMetrics  // see newStorefront
+	outboxWake chan struct{} // see runOutbox
+}
+
+// SetCatalog sets s.Catalog and the catalogs which are derived from it.
+func (s *Shop) SetCatalog(catalog digitalgoods.Catalog) {
+	catalog.SortTiers()
+	s.Catalog = catalog
+	s.brandCatalogs = digitalgoods.MakeBrandCatalogs(catalog)
+	s.purchaseCatalog = digitalgoods.MakePurchaseCatalog(catalog)
+	s.uploadCatalog = digitalgoods.MakeUploadCatalog(catalog)
+}
 
 var staffLang, _, _ = lang.MakeLanguages(nil, "de", "en").FromPath("de")
 
 func main() {
-	log.SetFlags(0)
-
 	// test mode
-	var test = flag.Bool("test", false, "use btcpay dummy store")
+	var test = flag.Bool("test", false, "use btcpay dummy store and dummy emailer")
+	var langs = flag.String("langs", "de,en", "comma-separated language prefixes of the storefront, the first one is the default")
+	var limitToStock = flag.Bool("limit-to-stock", false, "reject orders which exceed the stock")
+	var maxQuantity = flag.Int("max-quantity", 0, "maximum number of items per purchase, 0 means unlimited")
+	var logLevel = flag.String("log-level", "info", "minimum log level: debug, info, warn or error")
+	var storefrontID = flag.String("storefront", "", "ID of the storefront for commands other than serve, empty means the default storefront")
+	flag.Usage = usage
 	flag.Parse()
 
-	// order db
-	database, err := db.OpenDB()
+	cmd, args, ok := findCommand(flag.Args())
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", strings.Join(flag.Args(), " "))
+		usage()
+		os.Exit(2)
+	}
+	flag.CommandLine.Parse(args) // flags can follow the command
+	args = flag.Args()
+	if cmd.Run == nil && len(args) > 0 {
+		fmt.Fprintf(os.Stderr, "%s takes no arguments\n", cmd.Name)
+		os.Exit(2)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid log level: %v\n", err)
+		os.Exit(2)
+	}
+	slog.SetDefault(slog.New(logging.NewHandler(os.Stderr, level)))
+
+	// configuration
+	config, err := LoadConfig(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "config.json"))
 	if err != nil {
-		log.Printf("error opening database: %v", err)
+		slog.Error("error loading config", "err", err)
 		return
 	}
 
-	// btcpay
-	btcpayStore, err := btcpay.LoadConfig(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "btcpay.json"))
+	// order db
+	database, err := db.OpenDB(filepath.Join(os.Getenv("STATE_DIRECTORY"), "digitalgoods.sqlite3"))
 	if err != nil {
-		log.Printf("error loading btcpay store: %v", err)
+		slog.Error("error opening database", "err", err)
 		return
 	}
 
-	// emailer
-	var emailer email.Emailer
-	if *test {
-		emailer = email.DummyMailer{}
-		log.Println("\033[33m" + "warning: using dummy emailer" + "\033[0m")
-	} else {
-		emailer = email.Sendmail{
-			From: emailFrom,
+	// commands other than serve
+	if cmd.Run != nil {
+		err := runCommand(cmd, config, database, *storefrontID, strings.Split(*langs, ","), os.Stdout, args)
+		database.Close()
+		if err != nil {
+			slog.Error("command failed", "command", cmd.Name, "err", err)
+			os.Exit(1)
 		}
+		return
 	}
 
-	// foreign currency cash
-	ratesHistory, err := rates.MakeAndRun(filepath.Join(os.Getenv("STATE_DIRECTORY"), "rates.sqlite3"), GetBuyRates)
+	// notifiers
+	notifyConfig, err := notify.LoadConfig(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "notify.json"))
 	if err != nil {
-		log.Printf("error running rates daemon: %v", err)
+		slog.Error("error loading notify config", "err", err)
 		return
 	}
+	notifiers := notifyConfig.Notifiers()
+	if *test {
+		notifyServer := notifytest.NewServer()
+		defer notifyServer.Close()
+		maps.Copy(notifiers, notifyServer.Notifiers())
+		slog.Warn("using notification dummy server", "webhook", notifyServer.WebhookURL("test"))
+	}
+	notifiers["ntfysh"] = notify.Ntfysh{}
+
+	// foreign currency cash
+	var ratesHistory *rates.History
+	var ratesDB *sql.DB
+	if config.BuyRatesURL != "" {
+		ratesHistory, err = rates.MakeAndRun(filepath.Join(os.Getenv("STATE_DIRECTORY"), "rates.sqlite3"), config.GetBuyRates)
+		if err != nil {
+			slog.Error("error running rates daemon", "err", err)
+			return
+		}
+		ratesDB, err = sql.Open("sqlite3", filepath.Join(os.Getenv("STATE_DIRECTORY"), "rates.sqlite3")+"?_busy_timeout=10000") // for health checks only
+		if err != nil {
+			slog.Error("error opening rates database", "err", err)
+			return
+		}
+		defer ratesDB.Close()
+	}
 
 	// staff users
 	staffUsers, err := userdb.Open(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "users.json"))
 	if err != nil {
-		log.Printf("error opening userdb: %v", err)
+		slog.Error("error opening userdb", "err", err)
+		return
+	}
+
+	// staff api tokens
+	apiTokens, err := userdb.OpenTokens(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "api-tokens.json"))
+	if err != nil {
+		slog.Error("error opening api tokens", "err", err)
+		return
+	}
+
+	// discount codes
+	discounts, err := digitalgoods.LoadDiscounts(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "discounts.json"))
+	if err != nil {
+		slog.Error("error loading discounts", "err", err)
 		return
 	}
 
 	// customer sessions
 	custSessionsDB, err := sql.Open("sqlite3", filepath.Join(os.Getenv("STATE_DIRECTORY"), "customer-sessions.sqlite3"))
 	if err != nil {
-		log.Printf("error opening customer session database: %v", err)
+		slog.Error("error opening customer session database", "err", err)
 		return
 	}
 	defer custSessionsDB.Close()
@@ -129,12 +222,12 @@ func main() {
 		);
 		CREATE INDEX IF NOT EXISTS sessions_expiry_idx ON sessions(expiry);
 	`); err != nil {
-		log.Printf("error creating customer sessions table: %v", err)
+		slog.Error("error creating customer sessions table", "err", err)
 		return
 	}
 	custSessions := scs.New()
 	custSessions.Cookie.SameSite = http.SameSiteLaxMode // prevent CSRF
-	custSessions.Lifetime = 8 * time.Hour
+	custSessions.Lifetime = 31 * 24 * time.Hour         // like the deletion of unpaid purchases, see "my orders"
 	custSessions.Store = sqlite3store.New(custSessionsDB)
 
 	// staff sessions
@@ -142,93 +235,65 @@ func main() {
 	staffSessions.Cookie.SameSite = http.SameSiteLaxMode // prevent CSRF
 	staffSessions.Store = memstore.New()
 
-	// shop
-	s := &Shop{
-		Btcpay:           btcpayStore,
+	// storefronts
+	shared := Shop{
+		APITokens:        apiTokens,
+		CustomerSessions: custSessions,
 		Database:         database,
-		Emailer:          emailer,
-		Langs:            lang.MakeLanguages(nil, "de", "en"),
+		Discounts:        discounts,
+		Langs:            lang.MakeLanguages(nil, strings.Split(*langs, ",")...),
+		LimitToStock:     *limitToStock,
+		MaxQuantity:      *maxQuantity,
+		Notifiers:        notifiers,
+		RatesDB:          ratesDB,
 		RatesHistory:     ratesHistory,
-		CustomerSessions: custSessions,
+		SessionsDB:       custSessionsDB,
 		StaffSessions:    staffSessions,
 		StaffUsers:       staffUsers,
-		VATRate:          vatRate,
-	}
-
-	s.ProductFeed = productfeed.Feed{
-		ID:       "https://digitalgoods.proxysto.re",
-		Title:    "Digital Goods by ProxyStore",
-		Updated:  CatalogUpdated,
-		Products: catalog.Products(),
-	}
-
-	// payment methods (need shop variable)
-	s.PaymentMethods = []payment.Method{
-		&payment.BTCPay{
-			Purchases:    s,
-			RedirectPath: "/by-cookie",
-			Store:        btcpayStore,
-			ErrCreateInvoice: func(err error) http.Handler {
-				return s.frontendErr(fmt.Errorf("creating invoice: %w", err), "Error creating BTCPay invoice")
-			},
-			ErrWebhook: func(err error) http.Handler {
-				log.Printf("webhook error: %v", err)
-				ntfysh.Publish(ntfyshLog, "digitalgoods error", err.Error())
-				return nil
-			},
-			GetStatus: btcpayStore.StatusDaemon(),
-		},
-		payment.Cash{
-			AddressHTML: addressHTML,
-		},
-		payment.CashForeign{
-			AddressHTML: addressHTML,
-			History:     ratesHistory,
-			Purchases:   s,
-		},
-		payment.SEPA{
-			Account:   sepaAccount,
-			Purchases: s,
-		},
 	}
+	shared.SetCatalog(catalog)
 
-	s.ListenAndServe()
-}
-
-func (s *Shop) ListenAndServe() {
+	var shops []*Shop
+	for _, storefront := range config.All() {
+		s, closeStorefront, err := newStorefront(shared, storefront, config.LocalCustomerURL(), *test)
+		if err != nil {
+			slog.Error("error setting up storefront", "storefront", storefront.ID, "err", err)
+			return
+		}
+		defer closeStorefront()
+		shops = append(shops, s)
+	}
 
-	var stop = make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	Serve(config.CustomerAddr, shops)
+}
 
-	siteFiles, err := fs.Sub(html.Files, "digitalgoods.proxysto.re")
-	if err != nil {
-		log.Fatalf("error opening site dir: %v", err)
-	}
-	staticFiles, err := fs.Sub(html.Files, "digitalgoods.proxysto.re/static") // for staff router
+// CustomerHandler returns the handler of the customer http server.
+func (s *Shop) CustomerHandler() (http.Handler, error) {
+	staticFiles, err := fs.Sub(s.Site.Files, "static")
 	if err != nil {
-		log.Fatalf("error opening static dir: %v", err)
+		return nil, fmt.Errorf("opening static dir: %w", err)
 	}
 
-	staticSites, err := ssg.MakeWebsite(siteFiles, html.CustSite, s.Langs, func(r *http.Request, _ ssg.TemplateData) any {
+	staticSites, err := ssg.MakeWebsite(s.Site.Files, s.Site.Pages, s.Langs, func(r *http.Request, _ ssg.TemplateData) any {
 		return s.MakeTemplateData(r, "")
 	})
 	if err != nil {
-		log.Fatalf("error making static sites: %v", err)
+		return nil, fmt.Errorf("making static sites: %w", err)
 	}
 
-	// customer http server
-
-	var custRtr = httprouter.New()
+	var custRtr = newRouter("customer", s.metrics.httpDuration)
 	custRtr.ServeFiles("/static/*filepath", http.FS(httputil.ModTimeFS{staticFiles, time.Now()})) // can be omitted when ssg.Handler sets the modification time
 	for _, l := range s.Langs {
 		custRtr.Handler(http.MethodGet, "/"+l.Prefix, httputil.HandlerFunc(s.custOrderGet))
 		custRtr.Handler(http.MethodPost, "/"+l.Prefix, httputil.HandlerFunc(s.custOrderPost))
 		custRtr.Handler(http.MethodGet, "/"+l.Prefix+"/brand/:brand", httputil.HandlerFunc(s.custOrderGet))
 		custRtr.Handler(http.MethodPost, "/"+l.Prefix+"/brand/:brand", httputil.HandlerFunc(s.custOrderPost))
+		custRtr.Handler(http.MethodGet, "/"+l.Prefix+"/orders", httputil.HandlerFunc(s.custOrdersGet))
 		custRtr.Handler(http.MethodGet, "/"+l.Prefix+"/order/:id/:access-key", httputil.HandlerFunc(s.custPurchaseGet))
 		custRtr.Handler(http.MethodPost, "/"+l.Prefix+"/order/:id/:access-key", httputil.HandlerFunc(s.custPurchasePost))
 		custRtr.Handler(http.MethodGet, "/"+l.Prefix+"/order/:id/:access-key/:payment", httputil.HandlerFunc(s.custPurchaseGet))
 		custRtr.Handler(http.MethodPost, "/"+l.Prefix+"/order/:id/:access-key/:payment", httputil.HandlerFunc(s.custPurchasePost))
+		custRtr.HandlerFunc(http.MethodGet, "/"+l.Prefix+"/productfeed.xml", s.productFeed(l))
 	}
 	for _, method := range s.PaymentMethods {
 		// TODO use http.ServeMux and omit MethodGet/MethodPost here
@@ -238,20 +303,24 @@ func (s *Shop) ListenAndServe() {
 		custRtr.Handler(http.MethodPost, route, handler)
 	}
 	custRtr.HandlerFunc(http.MethodGet, "/by-cookie", s.byCookie)
-	custRtr.HandlerFunc(http.MethodGet, "/productfeed.xml", func(w http.ResponseWriter, r *http.Request) {
-		bs, _ := s.ProductFeed.Bytes()
-		w.Write(bs)
-	})
+	custRtr.HandlerFunc(http.MethodGet, "/api/catalog", s.publicCatalogGet)
+	custRtr.HandlerFunc(http.MethodGet, "/api/stock", s.publicStockGet)
+	if len(s.Langs) > 0 {
+		custRtr.HandlerFunc(http.MethodGet, "/productfeed.xml", s.productFeed(s.englishLang()))
+	}
 	custRtr.NotFound = staticSites.Handler(s.Langs.RedirectHandler())
 
-	shutdownCust := httputil.ListenAndServe(":9002", s.CustomerSessions.LoadAndSave(custRtr), stop)
-	defer shutdownCust()
-
-	log.Println("listening to port 9002")
+	return logging.Middleware(s.CustomerSessions.LoadAndSave(custRtr)), nil
+}
 
-	// staff http server
+// StaffHandler returns the handler of the staff http server.
+func (s *Shop) StaffHandler() (http.Handler, error) {
+	staticFiles, err := fs.Sub(s.Site.Files, "static")
+	if err != nil {
+		return nil, fmt.Errorf("opening static dir: %w", err)
+	}
 
-	var staffAuthRouter = httprouter.New()
+	var staffAuthRouter = newRouter("staff", s.metrics.httpDuration)
 	staffAuthRouter.HandlerFunc(http.MethodGet, "/", s.showErr(s.staffIndexGet))
 	staffAuthRouter.HandlerFunc(http.MethodGet, "/logout", s.showErr(s.staffLogoutGet))
 	staffAuthRouter.HandlerFunc(http.MethodGet, "/export/:from", s.showErr(s.staffExportGet))
@@ -263,14 +332,34 @@ func (s *Shop) ListenAndServe() {
 	staffAuthRouter.HandlerFunc(http.MethodPost, "/purchase/:id/mark-paid", s.showErr(s.staffPurchaseMarkPaidPost))
 	staffAuthRouter.HandlerFunc(http.MethodPost, "/purchase/:id/message", s.showErr(s.staffPurchaseMessagePost))
 
+	staffAuthRouter.HandlerFunc(http.MethodGet, "/outbox", s.showErr(s.staffOutboxGet))
+	staffAuthRouter.HandlerFunc(http.MethodPost, "/outbox/:id/delete", s.showErr(s.staffOutboxDeletePost))
+	staffAuthRouter.HandlerFunc(http.MethodPost, "/outbox/:id/retry", s.showErr(s.staffOutboxRetryPost))
+
 	staffAuthRouter.HandlerFunc(http.MethodGet, "/upload", s.showErr(s.staffSelectGet))
 	staffAuthRouter.HandlerFunc(http.MethodGet, "/upload/:stockid", s.showErr(s.staffUploadGet))
 	staffAuthRouter.HandlerFunc(http.MethodPost, "/upload/:stockid", returnErr(s.staffUploadPost))
 
-	var staffRtr = httprouter.New()
+	var staffRtr = newRouter("staff", s.metrics.httpDuration)
 	staffRtr.ServeFiles("/static/*filepath", http.FS(httputil.ModTimeFS{staticFiles, time.Now()}))
 	staffRtr.HandlerFunc(http.MethodGet, "/login", s.showErr(s.staffLoginGet))
 	staffRtr.HandlerFunc(http.MethodPost, "/login", s.showErr(s.staffLoginPost))
+
+	// health checks for the supervisor, unauthenticated
+	staffRtr.HandlerFunc(http.MethodGet, "/healthz", s.healthzGet)
+	staffRtr.HandlerFunc(http.MethodGet, "/readyz", s.readyzGet)
+
+	// json api, authenticated by token instead of session
+	staffRtr.HandlerFunc(http.MethodGet, "/api/v1/openapi.yaml", s.apiOpenAPIGet)
+	staffRtr.HandlerFunc(http.MethodGet, "/api/v1/stock", s.api(s.apiStockGet))
+	staffRtr.HandlerFunc(http.MethodPost, "/api/v1/stock/:stockid", s.api(s.apiStockPost))
+	staffRtr.HandlerFunc(http.MethodGet, "/api/v1/purchase/:id", s.api(s.apiPurchaseGet))
+	staffRtr.HandlerFunc(http.MethodPost, "/api/v1/purchase/:id/mark-paid", s.api(s.apiPurchaseMarkPaidPost))
+	staffRtr.HandlerFunc(http.MethodPost, "/api/v1/purchase/:id/message", s.api(s.apiPurchaseMessagePost))
+	staffRtr.HandlerFunc(http.MethodGet, "/api/v1/sales", s.api(s.apiSalesGet))
+
+	// prometheus metrics, authenticated by api token
+	staffRtr.HandlerFunc(http.MethodGet, "/metrics", returnErr(s.staffMetricsGet))
 	staffRtr.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if s.StaffSessions.Exists(r.Context(), "username") {
 			staffAuthRouter.ServeHTTP(w, r)
@@ -279,60 +368,83 @@ func (s *Shop) ListenAndServe() {
 		}
 	})
 
-	shutdownStaff := httputil.ListenAndServe("127.0.0.1:9003", http.NewCrossOriginProtection().Handler(s.StaffSessions.LoadAndSave(staffRtr)), stop)
-	defer shutdownStaff()
-
-	// cleanup bot
+	return logging.Middleware(http.NewCrossOriginProtection().Handler(s.StaffSessions.LoadAndSave(staffRtr))), nil
+}
 
-	var wg sync.WaitGroup
-	defer wg.Wait()
+// makeFeed returns the product feed in the given language. Availability is read from the current stock.
+func (s *Shop) makeFeed(l lang.Lang) (productfeed.Feed, error) {
+	stock, err := s.Database.GetStock()
+	if err != nil {
+		return productfeed.Feed{}, err
+	}
+	return productfeed.Feed{
+		ID:       s.BaseURL + "/" + l.Prefix,
+		Title:    s.Title,
+		Updated:  time.Now().UTC().Format(time.RFC3339), // availability is as of now
+		Products: s.Catalog.Products(stock, s.BaseURL, l),
+	}, nil
+}
 
-	go func() {
-		for ; true; <-time.Tick(12 * time.Hour) {
-			wg.Add(1)
-			if err := s.Database.Cleanup(); err != nil {
-				log.Printf("error cleaning up database: %v", err)
-			}
-			wg.Done()
+// productFeed generates the product feed on every request, so it reflects the current stock.
+func (s *Shop) productFeed(l lang.Lang) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		feed, err := s.makeFeed(l)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "product feed: error getting stock", "err", err)
+			http.Error(w, "error getting stock", http.StatusInternalServerError)
+			return
 		}
-	}()
-
-	// notify us
-	if err := s.Emailer.Send(emailFrom, "digitalgoods service started", []byte("the digitalgoods service has been started")); err != nil {
-		log.Println(err)
-	}
-	if err := ntfysh.Publish(ntfyshLog, "digitalgoods service started", "the digitalgoods service has been started"); err != nil {
-		log.Println(err)
+		bs, err := feed.Bytes()
+		if err != nil {
+			slog.ErrorContext(r.Context(), "product feed: error making feed", "err", err)
+			http.Error(w, "error making product feed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.Write(bs)
 	}
-
-	// run until we receive an interrupt or any of the listeners fails
-
-	log.Printf("running")
-	<-stop
-	log.Println("shutting down")
 }
 
 // frontend error handler, logs err and displays a message
 func (s *Shop) frontendErr(err error, message string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
-		html.CustError.Execute(w, html.CustErrorData{
+		s.Site.Error.Execute(w, html.CustErrorData{
 			TemplateData: s.MakeTemplateData(r, ""),
 			Message:      message,
 		})
 
 		if err != nil {
-			log.Printf("frontend error: %s", err)
-			ntfysh.Publish(ntfyshLog, "digitalgoods error", err.Error())
+			slog.ErrorContext(r.Context(), "frontend error", "err", err)
+			s.publishError(err)
 		}
 	})
 }
 
+// host returns the host of s.BaseURL, or an empty string.
+func (s *Shop) host() string {
+	u, err := url.Parse(s.BaseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// publishError publishes the error to the ntfy.sh topic, if it is configured.
+func (s *Shop) publishError(err error) {
+	if s.NtfyshTopic == "" {
+		return
+	}
+	if err := ntfysh.Publish(s.NtfyshTopic, "digitalgoods error", err.Error()); err != nil {
+		slog.Error("error publishing error", "err", err)
+	}
+}
+
 // frontend notfound handler, logs err and displays a message
 func (s *Shop) frontendNotFound(message string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
-		html.CustError.Execute(w, html.CustErrorData{
+		s.Site.Error.Execute(w, html.CustErrorData{
 			TemplateData: s.MakeTemplateData(r, ""),
 			Message:      message,
 		})
@@ -363,7 +475,7 @@ func (s *Shop) custOrderGet(w http.ResponseWriter, r *http.Request) http.Handler
 
 	availableEUCountries, availableNonEU, err := detect.Countries(r)
 	if err != nil {
-		log.Printf("error detecting countries: %v", err)
+		slog.WarnContext(r.Context(), "error detecting countries", "err", err)
 	}
 
 	// pre-select area if it's known
@@ -380,11 +492,11 @@ func (s *Shop) custOrderGet(w http.ResponseWriter, r *http.Request) http.Handler
 		return s.frontendErr(fmt.Errorf("getting stock: %w", err), l.Tr("Error getting stock from database. Please try again later."))
 	}
 
-	var cata = catalog
+	var cata = s.Catalog
 	var filterBrand string
 	if b := httprouter.ParamsFromContext(r.Context()).ByName("brand"); b != "" && len(b) < 100 {
 		b = strings.ToLower(b) // same as in MakeBrandCatalogs
-		if bc, ok := bcatalogs[b]; ok {
+		if bc, ok := s.brandCatalogs[b]; ok {
 			cata = bc.Categories
 			filterBrand = bc.Name
 		} else {
@@ -392,8 +504,8 @@ func (s *Shop) custOrderGet(w http.ResponseWriter, r *http.Request) http.Handler
 		}
 	}
 
-	err = html.CustOrder.Execute(w, &html.CustOrderData{
-		TemplateData: s.MakeTemplateData(r, filterBrand),
+	err = s.Site.Order.Execute(w, &html.CustOrderData{
+		TemplateData: s.withCurrency(r, s.MakeTemplateData(r, filterBrand)),
 
 		AvailableEUCountries: countries.TranslateAndSort(l, availableEUCountries, countries.Country("")),
 		AvailableNonEU:       availableNonEU,
@@ -417,7 +529,7 @@ func (s *Shop) custOrderPost(w http.ResponseWriter, r *http.Request) http.Handle
 
 	availableEUCountries, availableNonEU, err := detect.Countries(r)
 	if err != nil {
-		log.Printf("error detecting countries: %v", err)
+		slog.WarnContext(r.Context(), "error detecting countries", "err", err)
 	}
 
 	stock, err := s.Database.GetStock()
@@ -433,8 +545,9 @@ func (s *Shop) custOrderPost(w http.ResponseWriter, r *http.Request) http.Handle
 	var cart = digitalgoods.Cart{
 		Units: make(map[string]int), // key: article id + "-" + variant id, see type Cart
 	}
-	var orderQty = make(map[string]int) // variant only, in case of no errors
-	for _, category := range catalog {
+	var orderQty = make(map[string]int)  // variant only, in case of no errors
+	var brands = make(map[string]string) // key: variant id, for discount scope
+	for _, category := range s.Catalog {
 		for _, article := range category.Articles {
 			for _, variant := range article.Variants {
 				quantity, _ := strconv.Atoi(r.PostFormValue(article.ID + "-" + variant.ID))
@@ -445,6 +558,7 @@ func (s *Shop) custOrderPost(w http.ResponseWriter, r *http.Request) http.Handle
 				if quantity > 0 {
 					cart.Units[article.ID+"-"+variant.ID] += quantity
 					orderQty[variant.ID] += quantity
+					brands[variant.ID] = article.Brand
 				}
 			}
 		}
@@ -453,11 +567,11 @@ func (s *Shop) custOrderPost(w http.ResponseWriter, r *http.Request) http.Handle
 	// make order so that we have only one OrderRow for each variantID
 	var order digitalgoods.Order
 	for variantID, quantity := range orderQty {
-		if variant, ok := catalog.Variant(variantID); ok {
+		if variant, ok := s.Catalog.Variant(variantID); ok {
 			order = append(order, digitalgoods.OrderRow{
 				Quantity:  quantity,
 				VariantID: variant.ID,
-				ItemPrice: variant.Price,
+				ItemPrice: variant.PriceFor(quantity),
 			})
 		}
 	}
@@ -465,21 +579,29 @@ func (s *Shop) custOrderPost(w http.ResponseWriter, r *http.Request) http.Handle
 	// validate user input
 
 	co := &html.CustOrderData{
-		TemplateData: s.MakeTemplateData(r, ""),
+		TemplateData: s.withCurrency(r, s.MakeTemplateData(r, "")),
 
 		AvailableEUCountries: countries.TranslateAndSort(l, availableEUCountries, selectedEUCountry),
 		AvailableNonEU:       availableNonEU,
-		Catalog:              catalog,
+		Catalog:              s.Catalog,
 		Stock:                stock,
 
 		Cart:      &cart,
 		Area:      r.PostFormValue("area"),
 		EUCountry: string(selectedEUCountry),
+
+		DiscountCode: strings.TrimSpace(r.PostFormValue("discount-code")),
 	}
 
 	if len(order) == 0 {
 		co.OrderErr = true
-		html.CustOrder.Execute(w, co)
+		s.Site.Order.Execute(w, co)
+		return nil
+	}
+
+	co.QtyErrs, co.MaxQtyErr = s.validateQuantities(l, order, stock)
+	if len(co.QtyErrs) > 0 || co.MaxQtyErr > 0 {
+		s.Site.Order.Execute(w, co)
 		return nil
 	}
 
@@ -490,9 +612,29 @@ func (s *Shop) custOrderPost(w http.ResponseWriter, r *http.Request) http.Handle
 		country = countries.Country(co.EUCountry)
 		if !country.InEU() {
 			co.CountryErr = true
-			html.CustOrder.Execute(w, co)
+			s.Site.Order.Execute(w, co)
+			return nil
+		}
+	}
+
+	var maxUses int // of the discount code
+	if co.DiscountCode != "" {
+		discount, ok := s.Discounts.Get(co.DiscountCode)
+		switch {
+		case !ok:
+			co.DiscountErr = l.Tr("This discount code is not valid.")
+		case !discount.ValidAt(time.Now().Format(digitalgoods.DateFmt)):
+			co.DiscountErr = l.Tr("This discount code has expired or is not valid yet.")
+		case !order.ApplyDiscount(discount, brands):
+			co.DiscountErr = l.Tr("This discount code does not apply to the selected products.")
+		case order.Sum() <= 0:
+			co.DiscountErr = l.Tr("This discount code can't be used for this order.") // we can't invoice a sum of zero
+		}
+		if co.DiscountErr != "" {
+			s.Site.Order.Execute(w, co)
 			return nil
 		}
+		maxUses = discount.MaxUses
 	}
 
 	purchase := &digitalgoods.Purchase{
@@ -503,18 +645,58 @@ func (s *Shop) custOrderPost(w http.ResponseWriter, r *http.Request) http.Handle
 		CreateDate:  time.Now().Format("2006-01-02"),
 		DeleteDate:  time.Now().AddDate(0, 0, 31).Format("2006-01-02"),
 		CountryCode: string(country),
+		Lang:        l.Prefix,
 	}
 
-	if err := s.Database.InsertPurchase(purchase); err != nil {
+	if err := s.Database.InsertPurchase(purchase, maxUses); err != nil {
+		if errors.Is(err, digitalgoods.ErrDiscountUsedUp) {
+			co.DiscountErr = l.Tr("This discount code has been used up.")
+			s.Site.Order.Execute(w, co)
+			return nil
+		}
 		return s.frontendErr(fmt.Errorf("inserting purchase: %w", err), l.Tr("Error inserting purchase into database. Please try again later."))
 	}
 
 	// set cookie
 	redirectPath := path.Join("/", l.Prefix, "order", purchase.ID, purchase.AccessKey)
 	s.CustomerSessions.Put(r.Context(), "redirect-path", redirectPath)
+	s.rememberPurchase(r, purchase)
 	return http.RedirectHandler(redirectPath, http.StatusSeeOther)
 }
 
+// validateQuantities checks the order against the maximum quantities of the variants and of the shop, and against the stock if s.LimitToStock is set.
+// If the shop maximum is exceeded, it is returned as the second value.
+func (s *Shop) validateQuantities(l lang.Lang, order digitalgoods.Order, stock digitalgoods.Stock) (map[string]*html.QtyErr, int) {
+	var errs = make(map[string]*html.QtyErr)
+	var stockQty = make(map[string]int) // key: stock id, variants can share stock
+	var total int
+	for _, row := range order {
+		if variant, ok := s.Catalog.Variant(row.VariantID); ok {
+			stockQty[variant.StockID()] += row.Quantity
+		}
+		total += row.Quantity
+	}
+
+	for _, row := range order {
+		variant, ok := s.Catalog.Variant(row.VariantID)
+		if !ok {
+			continue
+		}
+		switch {
+		case variant.MaxQuantity > 0 && row.Quantity > variant.MaxQuantity:
+			errs[variant.ID] = &html.QtyErr{Message: l.Tr("Maximum quantity per order:"), Max: variant.MaxQuantity}
+		case s.LimitToStock && stockQty[variant.StockID()] > stock[variant.StockID()]:
+			errs[variant.ID] = &html.QtyErr{Message: l.Tr("Not enough in stock. Available:"), Max: stock[variant.StockID()]}
+		}
+	}
+
+	var maxErr int
+	if s.MaxQuantity > 0 && total > s.MaxQuantity {
+		maxErr = s.MaxQuantity
+	}
+	return errs, maxErr
+}
+
 func (s *Shop) custPurchaseGet(w http.ResponseWriter, r *http.Request) http.Handler {
 	l, _, _ := s.Langs.FromPath(r.URL.Path)
 	params := httprouter.ParamsFromContext(r.Context())
@@ -522,14 +704,30 @@ func (s *Shop) custPurchaseGet(w http.ResponseWriter, r *http.Request) http.Hand
 	if err != nil {
 		return s.frontendNotFound(l.Tr("There is no such purchase, or it has been deleted, or the URL is incorrect."))
 	}
+	s.rememberPurchase(r, purchase)
+
+	// downloads share the route with payment methods
+	if format, ok := strings.CutPrefix(params.ByName("payment"), "codes."); ok {
+		return s.custExport(w, l, purchase, format)
+	}
+	if name, ok := strings.CutPrefix(params.ByName("payment"), "qr-"); ok {
+		return s.custQRCode(w, l, purchase, name)
+	}
+
+	return s.custPurchasePage(w, r, l, purchase, "")
+}
 
-	err = html.CustPurchase.Execute(w, &html.CustPurchaseData{
-		TemplateData: s.MakeTemplateData(r, ""),
+// custPurchasePage executes the purchase template. If notifyErr is not empty, it is shown at the notification form.
+func (s *Shop) custPurchasePage(w http.ResponseWriter, r *http.Request, l lang.Lang, purchase *digitalgoods.Purchase, notifyErr string) http.Handler {
+	err := s.Site.Purchase.Execute(w, &html.CustPurchaseData{
+		TemplateData: s.withCurrency(r, s.MakeTemplateData(r, "")),
 
-		ActivePaymentMethod: params.ByName("payment"),
+		ActivePaymentMethod: httprouter.ParamsFromContext(r.Context()).ByName("payment"),
+		NotifyErr:           notifyErr,
+		NotifyProtos:        s.notifyProtos(),
 		PaymentMethods:      s.PaymentMethods,
 		Purchase:            purchase,
-		PurchaseArticles:    digitalgoods.MakePurchaseArticles(pcatalog, purchase),
+		PurchaseArticles:    digitalgoods.MakePurchaseArticles(s.purchaseCatalog, purchase),
 		URL:                 httputil.SchemeHost(r) + path.Join("/", l.Prefix, "order", purchase.ID, purchase.AccessKey),
 	})
 	if err != nil {
@@ -548,6 +746,7 @@ func (s *Shop) custPurchasePost(w http.ResponseWriter, r *http.Request) http.Han
 
 	notifyProto := r.PostFormValue("notify-proto")
 	notifyAddr := strings.TrimSpace(r.PostFormValue("notify-addr"))
+	notifyKey := "" // openpgp only
 	if len(notifyAddr) > 1024 {
 		notifyAddr = notifyAddr[:1024]
 	}
@@ -564,21 +763,42 @@ func (s *Shop) custPurchasePost(w http.ResponseWriter, r *http.Request) http.Han
 		}
 	}
 
-	switch notifyProto {
-	case "email":
-		notifyAddr = strings.TrimSpace(notifyAddr)
+	// use openpgp if a key is given
+	if notifyProto == "email" && strings.TrimSpace(r.PostFormValue("notify-key")) != "" {
+		notifyProto = "openpgp"
+	}
+
+	if notifyProto == "openpgp" {
+		notifyKey = strings.TrimSpace(r.PostFormValue("notify-key"))
 		if !email.AddressValid(notifyAddr) {
 			notifyAddr = ""
+			notifyKey = ""
+			notifyProto = ""
+		} else if err := validatePublicKey(notifyKey); err != nil || len(notifyKey) > 64*1024 {
+			// show the form again with the input, nothing is saved
+			purchase.NotifyProto = notifyProto
+			purchase.NotifyAddr = notifyAddr
+			purchase.NotifyKey = notifyKey
+			if errors.Is(err, errMultipleKeys) {
+				return s.custPurchasePage(w, r, l, purchase, l.Tr("Please paste exactly one OpenPGP public key."))
+			}
+			return s.custPurchasePage(w, r, l, purchase, l.Tr("This OpenPGP public key can't be used for encryption."))
+		}
+	} else if notifier, ok := s.Notifiers[notifyProto]; ok {
+		var err error
+		if notifyAddr, err = notifier.ValidateAddress(notifyAddr); err != nil {
+			notifyAddr = ""
+			notifyProto = ""
 		}
-	case "ntfysh":
-		notifyAddr = ntfysh.ValidateAddress(notifyAddr)
-	default:
+	} else {
 		notifyAddr = ""
 		notifyProto = ""
 	}
 
 	purchase.NotifyProto = notifyProto
 	purchase.NotifyAddr = notifyAddr
+	purchase.NotifyKey = notifyKey
+	purchase.Lang = l.Prefix
 	if err := s.Database.SetNotify(purchase); err != nil {
 		return s.frontendErr(fmt.Errorf("saving notify contact: %w", err), l.Tr("Error saving contact information. Please try again later."))
 	}
@@ -586,6 +806,74 @@ func (s *Shop) custPurchasePost(w http.ResponseWriter, r *http.Request) http.Han
 	return http.RedirectHandler(r.URL.Path+"#notify", http.StatusSeeOther)
 }
 
+// notifyProtos returns the available notification protocols.
+func (s *Shop) notifyProtos() map[string]bool {
+	var protos = map[string]bool{"openpgp": true}
+	for proto := range s.Notifiers {
+		protos[proto] = true
+	}
+	return protos
+}
+
+// englishLang returns English if it is a shop language, else the first shop language.
+func (s *Shop) englishLang() lang.Lang {
+	var result lang.Lang
+	for i, l := range s.Langs {
+		if i == 0 || l.Prefix == "en" {
+			result = l
+		}
+	}
+	return result
+}
+
+// maxRememberedPurchases is the number of purchases which are kept in the customer session
+const maxRememberedPurchases = 20
+
+// rememberPurchase adds the purchase ID and access key to the front of the "purchases" list in the customer session, so the customer can find it on the "my orders" page.
+func (s *Shop) rememberPurchase(r *http.Request, purchase *digitalgoods.Purchase) {
+	key := purchase.ID + "/" + purchase.AccessKey
+	purchases, _ := s.CustomerSessions.Get(r.Context(), "purchases").([]string)
+	if len(purchases) > 0 && purchases[0] == key {
+		return // don't write the session
+	}
+	purchases = slices.DeleteFunc(slices.Clone(purchases), func(p string) bool { return p == key })
+	purchases = slices.Insert(purchases, 0, key)
+	if len(purchases) > maxRememberedPurchases {
+		purchases = purchases[:maxRememberedPurchases]
+	}
+	s.CustomerSessions.Put(r.Context(), "purchases", purchases)
+}
+
+// custOrdersGet lists the purchases which are stored in the customer session. Deleted purchases are removed from the session.
+func (s *Shop) custOrdersGet(w http.ResponseWriter, r *http.Request) http.Handler {
+	l, _, _ := s.Langs.FromPath(r.URL.Path)
+
+	keys, _ := s.CustomerSessions.Get(r.Context(), "purchases").([]string)
+	var purchases []*digitalgoods.Purchase
+	var remaining []string
+	for _, key := range keys {
+		id, accessKey, _ := strings.Cut(key, "/")
+		purchase, err := s.Database.GetPurchaseByIDAndAccessKey(id, accessKey)
+		if err != nil {
+			continue
+		}
+		purchases = append(purchases, purchase)
+		remaining = append(remaining, key)
+	}
+	if len(remaining) < len(keys) {
+		s.CustomerSessions.Put(r.Context(), "purchases", remaining)
+	}
+
+	err := s.Site.Orders.Execute(w, &html.CustOrdersData{
+		TemplateData: s.withCurrency(r, s.MakeTemplateData(r, "")),
+		Purchases:    purchases,
+	})
+	if err != nil {
+		return s.frontendErr(fmt.Errorf("executing customer orders template: %w", err), l.Tr("Error displaying website. Please try again later."))
+	}
+	return nil
+}
+
 func (s *Shop) byCookie(w http.ResponseWriter, r *http.Request) {
 	// TODO maybe save language in cookie and redirect to user's locale
 	if redirectPath := s.CustomerSessions.GetString(r.Context(), "redirect-path"); redirectPath != "" {
@@ -605,14 +893,30 @@ func (s *Shop) staffIndexGet(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+
+	failedNotifications, err := s.Database.GetFailedNotifications()
+	if err != nil {
+		return err
+	}
+	var deadNotifications int
+	for _, entry := range failedNotifications {
+		if entry.Dead {
+			deadNotifications++
+		}
+	}
+
 	return html.StaffIndex.Execute(w, struct {
-		Catalog        digitalgoods.UploadCatalog
-		Stock          digitalgoods.Stock
-		Underdelivered []string
+		Catalog             digitalgoods.UploadCatalog
+		DeadNotifications   int
+		MissingTranslations []digitalgoods.MissingTranslation
+		Stock               digitalgoods.Stock
+		Underdelivered      []string
 	}{
-		Catalog:        ucatalog,
-		Stock:          stock,
-		Underdelivered: underdelivered,
+		Catalog:             s.uploadCatalog,
+		DeadNotifications:   deadNotifications,
+		MissingTranslations: s.Catalog.MissingTranslations(s.Langs),
+		Stock:               stock,
+		Underdelivered:      underdelivered,
 	})
 }
 
@@ -639,24 +943,38 @@ func (s *Shop) staffLogoutGet(w http.ResponseWriter, r *http.Request) error {
 
 func (s *Shop) staffExportGet(w http.ResponseWriter, r *http.Request) error {
 	minDate := httprouter.ParamsFromContext(r.Context()).ByName("from")
-	sales, err := s.Database.GetSales(minDate)
+	sales, err := s.getSales(minDate)
 	if err != nil {
 		return err
 	}
 
-	// set VAT rates
-	for i := range sales {
-		sales[i].VATRate, sales[i].Difftax = s.VATRate(sales[i])
-	}
-
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	return writeSales(w, sales)
+}
+
+// writeSales writes the sales as CSV.
+func writeSales(w io.Writer, sales []digitalgoods.Sale) error {
 	out := csv.NewWriter(w)
 	out.Write([]string{"pay_date", "id", "country", "gross", "difftax", "vat_rate", "description", "is_service"})
 	for _, sale := range sales {
 		out.Write([]string{sale.PayDate, sale.ID, sale.Country, strconv.Itoa(sale.GrossSum), strconv.Itoa(sale.Difftax), sale.VATRate, sale.Name, "true"})
 	}
 	out.Flush()
-	return nil
+	return out.Error()
+}
+
+// getSales returns the sales since minDate, including their VAT rates.
+func (s *Shop) getSales(minDate string) ([]digitalgoods.Sale, error) {
+	sales, err := s.Database.GetSales(minDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// set VAT rates
+	for i := range sales {
+		sales[i].VATRate, sales[i].Difftax = s.VATRate(sales[i])
+	}
+	return sales, nil
 }
 
 func (s *Shop) staffPurchaseSearchGet(w http.ResponseWriter, r *http.Request) error {
@@ -698,7 +1016,10 @@ func (s *Shop) staffPurchaseGet(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
-	currencyOptions, _ := s.RatesHistory.Options(purchase.CreateDate, float64(purchase.Ordered.Sum())/100.0)
+	var currencyOptions []rates.Option
+	if s.RatesHistory != nil {
+		currencyOptions, _ = s.RatesHistory.Options(purchase.CreateDate, float64(purchase.Ordered.Sum())/100.0)
+	}
 
 	return html.StaffPurchase.Execute(w, struct {
 		*digitalgoods.Purchase
@@ -709,7 +1030,7 @@ func (s *Shop) staffPurchaseGet(w http.ResponseWriter, r *http.Request) error {
 		Purchase:         purchase,
 		CurrencyOptions:  currencyOptions,
 		EUCountries:      countries.TranslateAndSort(staffLang, countries.EuropeanUnion, countries.Country("")),
-		PurchaseArticles: digitalgoods.MakePurchaseArticles(pcatalog, purchase),
+		PurchaseArticles: digitalgoods.MakePurchaseArticles(s.purchaseCatalog, purchase),
 	})
 }
 
@@ -720,10 +1041,10 @@ func (s *Shop) staffPurchaseGetLinkPost(w http.ResponseWriter, r *http.Request)
 		return err
 	}
 
-	if err := s.Emailer.Send(emailFrom, fmt.Sprintf("digitalgoods: purchase link %s shown", purchase.ID), []byte("a purchase link has been shown in the backend")); err != nil {
-		log.Println(err)
+	if err := s.Emailer.Send(s.EmailFrom, fmt.Sprintf("digitalgoods: purchase link %s shown", purchase.ID), []byte("a purchase link has been shown in the backend")); err != nil {
+		slog.ErrorContext(r.Context(), "error sending purchase link email", "purchase", purchase.ID, "err", err)
 	}
-	http.Redirect(w, r, "https://digitalgoods.proxysto.re"+path.Join("/", "order", purchase.ID, purchase.AccessKey), http.StatusFound) // no language prefix in url
+	http.Redirect(w, r, s.BaseURL+path.Join("/", "order", purchase.ID, purchase.AccessKey), http.StatusFound) // no language prefix in url
 	return nil
 }
 
@@ -736,20 +1057,27 @@ func (s *Shop) staffPurchaseMarkPaidPost(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		return err
 	}
-	if countryCode := r.PostFormValue("country"); countryCode != "" {
+	if err := s.markPaid(purchase, r.PostFormValue("country")); err != nil {
+		return err
+	}
+	http.Redirect(w, r, fmt.Sprintf("/purchase/%s", purchase.ID), http.StatusSeeOther)
+	return nil
+}
+
+// markPaid optionally adjusts the country, then settles the purchase. The customer is notified through the outbox.
+func (s *Shop) markPaid(purchase *digitalgoods.Purchase, countryCode string) error {
+	if countryCode != "" {
 		if purchase.CountryCode != countryCode {
 			if err := s.Database.SetCountry(purchase, countryCode); err != nil {
 				return err
 			}
 		}
 	}
-	if err := s.Database.SetSettled(purchase, catalog); err != nil {
-		return err
-	}
-	if err := s.NotifyPaymentReceived(purchase); err != nil {
+	slog.Info("purchase marked as paid", "purchase", purchase.ID)
+	if err := s.Database.SetSettled(purchase, s.Catalog); err != nil {
 		return err
 	}
-	http.Redirect(w, r, fmt.Sprintf("/purchase/%s", purchase.ID), http.StatusSeeOther)
+	s.wakeOutbox()
 	return nil
 }
 
@@ -759,38 +1087,29 @@ func (s *Shop) staffPurchaseMessagePost(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		return err
 	}
-	var message = r.PostFormValue("message")
+	if err := s.setMessage(purchase, r.PostFormValue("message")); err != nil {
+		return err
+	}
+	http.Redirect(w, r, fmt.Sprintf("/purchase/%s", purchase.ID), http.StatusSeeOther)
+	return nil
+}
+
+func (s *Shop) setMessage(purchase *digitalgoods.Purchase, message string) error {
 	if len(message) > 1000 {
 		message = message[:1000]
 	}
 	if err := s.Database.SetMessage(purchase, message, time.Now().AddDate(0, 0, 31).Format("2006-01-02")); err != nil {
 		return err
 	}
-	http.Redirect(w, r, fmt.Sprintf("/purchase/%s", purchase.ID), http.StatusSeeOther)
+	s.wakeOutbox()
 	return nil
 }
 
 func (s *Shop) staffSelectGet(w http.ResponseWriter, r *http.Request) error {
-	underdeliveredPurchaseIDs, err := s.Database.GetPurchases(digitalgoods.StatusUnderdelivered)
+	underdelivered, err := s.getUnderdelivered()
 	if err != nil {
 		return err
 	}
-	underdelivered := make(map[string]int)
-	for _, purchaseID := range underdeliveredPurchaseIDs {
-		purchase, err := s.Database.GetPurchaseByID(purchaseID)
-		if err != nil {
-			return err
-		}
-		unfulfilled, err := purchase.GetUnfulfilled()
-		if err != nil {
-			return err
-		}
-		for _, orderRow := range unfulfilled {
-			if variant, ok := catalog.Variant(orderRow.VariantID); ok {
-				underdelivered[variant.StockID()] += orderRow.Quantity
-			}
-		}
-	}
 
 	stock, err := s.Database.GetStock()
 	if err != nil {
@@ -802,15 +1121,40 @@ func (s *Shop) staffSelectGet(w http.ResponseWriter, r *http.Request) error {
 		Stock          digitalgoods.Stock
 		Underdelivered map[string]int // key: variant id
 	}{
-		Catalog:        ucatalog,
+		Catalog:        s.uploadCatalog,
 		Stock:          stock,
 		Underdelivered: underdelivered,
 	})
 }
 
+// getUnderdelivered returns the unfulfilled quantities of all underdelivered purchases by stock ID.
+func (s *Shop) getUnderdelivered() (map[string]int, error) {
+	underdeliveredPurchaseIDs, err := s.Database.GetPurchases(digitalgoods.StatusUnderdelivered)
+	if err != nil {
+		return nil, err
+	}
+	underdelivered := make(map[string]int)
+	for _, purchaseID := range underdeliveredPurchaseIDs {
+		purchase, err := s.Database.GetPurchaseByID(purchaseID)
+		if err != nil {
+			return nil, err
+		}
+		unfulfilled, err := purchase.GetUnfulfilled()
+		if err != nil {
+			return nil, err
+		}
+		for _, orderRow := range unfulfilled {
+			if variant, ok := s.Catalog.Variant(orderRow.VariantID); ok {
+				underdelivered[variant.StockID()] += orderRow.Quantity
+			}
+		}
+	}
+	return underdelivered, nil
+}
+
 func (s *Shop) staffUploadGet(w http.ResponseWriter, r *http.Request) error {
 	stockID := httprouter.ParamsFromContext(r.Context()).ByName("stockid")
-	unit, ok := ucatalog.UploadStockUnit(stockID)
+	unit, ok := s.uploadCatalog.UploadStockUnit(stockID)
 	if !ok {
 		return errors.New("no variants found")
 	}
@@ -832,13 +1176,22 @@ func (s *Shop) staffUploadGet(w http.ResponseWriter, r *http.Request) error {
 
 func (s *Shop) staffUploadPost(w http.ResponseWriter, r *http.Request) error {
 	stockID := httprouter.ParamsFromContext(r.Context()).ByName("stockid")
-	if _, ok := ucatalog.UploadStockUnit(stockID); !ok {
+	if _, ok := s.uploadCatalog.UploadStockUnit(stockID); !ok {
 		return errors.New("stock unit not found")
 	}
 
-	codes := strings.Fields(r.PostFormValue("codes"))
+	if err := s.addToStock(stockID, strings.Fields(r.PostFormValue("codes"))); err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/upload#"+stockID, http.StatusSeeOther)
+	return nil
+}
+
+// addToStock shuffles the codes, adds them to the stock and fulfils underdelivered purchases.
+func (s *Shop) addToStock(stockID string, codes []string) error {
 	for _, code := range codes {
-		log.Printf("adding code to stock: %s %s", stockID, digitalgoods.Mask(code))
+		slog.Info("adding code to stock", "stock", stockID, "code", code)
 	}
 
 	// shuffle codes
@@ -849,16 +1202,15 @@ func (s *Shop) staffUploadPost(w http.ResponseWriter, r *http.Request) error {
 	for _, code := range codes {
 		err := s.Database.AddToStock(stockID, code)
 		if err != nil {
-			log.Println(err)
+			slog.Error("error adding code to stock", "stock", stockID, "code", code, "err", err)
 			return err
 		}
 	}
 
-	if err := s.Database.FulfilUnderdelivered(catalog); err != nil {
+	if err := s.Database.FulfilUnderdelivered(s.Catalog); err != nil {
 		return err
 	}
-
-	http.Redirect(w, r, "/upload#"+stockID, http.StatusSeeOther)
+	s.wakeOutbox()
 	return nil
 }
 
@@ -890,10 +1242,12 @@ func (s *Shop) SetPurchasePaid(id, paymentKey, methodName string) error {
 	if err != nil {
 		return err
 	}
-	if err := s.Database.SetSettled(purchase, catalog); err != nil {
+	slog.Info("purchase paid", "purchase", purchase.ID, "method", methodName)
+	if err := s.Database.SetSettled(purchase, s.Catalog); err != nil {
 		return err
 	}
-	return s.NotifyPaymentReceived(purchase)
+	s.wakeOutbox()
+	return nil
 }
 
 func (s *Shop) SetPurchaseProcessing(id, paymentKey string) error {
@@ -901,29 +1255,72 @@ func (s *Shop) SetPurchaseProcessing(id, paymentKey string) error {
 	if err != nil {
 		return err
 	}
-	return s.Database.SetProcessing(purchase)
+	if purchase.Status != digitalgoods.StatusNew {
+		return nil // webhook redelivery, or the payment has already been settled
+	}
+	slog.Info("purchase payment processing", "purchase", purchase.ID)
+	if err := s.Database.SetProcessing(purchase); err != nil {
+		return err
+	}
+	s.wakeOutbox()
+	return nil
+}
+
+// purchaseLang returns the language of the purchase, or English if it is not a shop language.
+func (s *Shop) purchaseLang(purchase *digitalgoods.Purchase) lang.Lang {
+	for _, l := range s.Langs {
+		if l.Prefix == purchase.Lang {
+			return l
+		}
+	}
+	return s.englishLang()
 }
 
-func (s *Shop) NotifyPaymentReceived(purchase *digitalgoods.Purchase) error {
-	const subject = "digitalgoods.proxysto.re payment received"
-	const msg = "We have received your payment. Please download your vouchers within the next 30 days."
+// notify sends a notification about the event to the customer in the language of the purchase. If the event means that all items have been delivered, OpenPGP-encrypted emails contain the codes, and the contact data is deleted afterwards.
+func (s *Shop) notify(purchase *digitalgoods.Purchase, event digitalgoods.Event) error {
+	if purchase.NotifyProto == "" || purchase.NotifyAddr == "" {
+		return nil
+	}
+	t, ok := html.Notifications[event]
+	if !ok {
+		return fmt.Errorf("unknown event %q", event)
+	}
+	// codes are sent and the contact is deleted when all items have been delivered, regardless of the current status of the purchase
+	allDelivered := event == digitalgoods.EventDelivered || event == digitalgoods.EventRemainingDelivered
 
-	switch purchase.NotifyProto {
-	case "email":
-		err := s.Emailer.Send(purchase.NotifyAddr, subject, []byte(msg))
-		if err != nil {
-			return fmt.Errorf("sending email notification: %w", err)
+	l := s.purchaseLang(purchase)
+	subject, body, err := html.RenderNotification(t, html.NotifyData{Lang: l, Host: s.host(), Purchase: purchase})
+	if err != nil {
+		return fmt.Errorf("rendering notification: %w", err)
+	}
+
+	if purchase.NotifyProto == "openpgp" {
+		var plaintext bytes.Buffer
+		plaintext.WriteString(body + "\n\n")
+		if allDelivered {
+			writeText(&plaintext, l.Tr("Order")+" "+purchase.ID, exportItems(digitalgoods.MakePurchaseArticles(s.purchaseCatalog, purchase)))
 		}
-	case "ntfysh":
-		err := ntfysh.Publish(purchase.NotifyAddr, subject, msg)
+		encrypted, err := encryptTo(purchase.NotifyKey, plaintext.Bytes())
 		if err != nil {
-			return fmt.Errorf("sending ntfysh notification: %w", err)
+			return fmt.Errorf("encrypting openpgp notification: %w", err)
+		}
+		if err := s.Emailer.Send(purchase.NotifyAddr, subject, []byte(encrypted)); err != nil {
+			return fmt.Errorf("sending openpgp notification: %w", notify.HideAddress(err, purchase.NotifyAddr))
+		}
+	} else {
+		notifier, ok := s.Notifiers[purchase.NotifyProto]
+		if !ok {
+			return fmt.Errorf("unknown notify proto %q", purchase.NotifyProto)
+		}
+		if err := notifier.Send(purchase.NotifyAddr, subject, body); err != nil {
+			return fmt.Errorf("sending %s notification: %w", purchase.NotifyProto, notify.HideAddress(err, purchase.NotifyAddr))
 		}
 	}
 
-	if purchase.Status == digitalgoods.StatusFinalized {
+	if allDelivered {
 		purchase.NotifyProto = ""
 		purchase.NotifyAddr = ""
+		purchase.NotifyKey = ""
 		err := s.Database.SetNotify(purchase)
 		if err != nil {
 			return fmt.Errorf("removing notify data from database: %w", err)
@@ -941,7 +1338,37 @@ func (s *Shop) MakeTemplateData(r *http.Request, filterBrand string) html.Templa
 			Path:      path,
 		},
 		Active:      "digitalgoods",
+		HasOrders:   s.CustomerSessions.Exists(r.Context(), "purchases"),
 		Onion:       strings.HasSuffix(r.Host, ".onion") || strings.Contains(r.Host, ".onion:"),
 		FilterBrand: filterBrand,
+		Title:       s.Title,
+		BaseURL:     s.BaseURL,
+	}
+}
+
+// withCurrency adds the available currencies and the selected one to the template data, so prices can be shown in that currency as well.
+// The selection is taken from the "currency" query parameter and remembered in the customer session.
+func (s *Shop) withCurrency(r *http.Request, td html.TemplateData) html.TemplateData {
+	if s.RatesHistory == nil {
+		return td
+	}
+	options, err := s.RatesHistory.Options(time.Now().Format(digitalgoods.DateFmt), 1000.0) // large amount for precision
+	if err != nil {
+		return td
+	}
+	if r.URL.Query().Has("currency") {
+		s.CustomerSessions.Put(r.Context(), "currency", r.URL.Query().Get("currency"))
+	}
+	selected := s.CustomerSessions.GetString(r.Context(), "currency")
+	for _, option := range options {
+		if option.Currency == "EUR" {
+			continue
+		}
+		td.CurrencyOptions = append(td.CurrencyOptions, option.Currency)
+		if option.Currency == selected {
+			td.Currency = option.Currency
+			td.CurrencyRate = option.Price / 1000.0
+		}
 	}
+	return td
 }
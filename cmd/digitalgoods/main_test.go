@@ -0,0 +1,817 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	stdhtml "html"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/digitalgoods/btcpaytest"
+	"github.com/dys2p/digitalgoods/db"
+	"github.com/dys2p/digitalgoods/html"
+	"github.com/dys2p/digitalgoods/notify"
+	"github.com/dys2p/digitalgoods/notifytest"
+	"github.com/dys2p/digitalgoods/userdb"
+	"github.com/dys2p/eco/lang"
+	"github.com/dys2p/eco/payment"
+)
+
+// testCatalog returns the fixture catalog. Both variants of "shared" use a common stock.
+func testCatalog() digitalgoods.Catalog {
+	return digitalgoods.Catalog{
+		{
+			Name: map[string]string{"de": "Test", "en": "Test"},
+			Articles: []digitalgoods.Article{
+				{
+					Brand: "Test",
+					Name:  "Test Voucher",
+					ID:    "voucher",
+					Desc: map[string]digitalgoods.Description{
+						"de": {About: "Ein Testgutschein"},
+						"en": {About: "A test voucher"},
+					},
+					Variants: []digitalgoods.Variant{
+						{ID: "voucher-5", Name: "Test Voucher 5", Price: 500, QRCode: true, Tiers: []digitalgoods.Tier{{MinQuantity: 50, Price: 400}, {MinQuantity: 10, Price: 450}}},
+						{ID: "voucher-10", Name: "Test Voucher 10", Price: 1000, MaxQuantity: 10, WarnStock: 5},
+					},
+				},
+				{
+					Brand: "Test",
+					Name:  "Shared Stock",
+					ID:    "shared",
+					Variants: []digitalgoods.Variant{
+						{ID: "shared-a", Name: "Shared A", Price: 300, OptionalStockID: "shared"},
+						{ID: "shared-b", Name: "Shared B", Price: 300, OptionalStockID: "shared"},
+					},
+				},
+			},
+		},
+	}
+}
+
+type testUsers struct{}
+
+func (testUsers) Authenticate(username, password string) error {
+	if username == "staff" && password == "staff" {
+		return nil
+	}
+	return errors.New("wrong username or password")
+}
+
+// testToken is accepted by testTokens.
+const testToken = "test-token"
+
+type testTokens struct{}
+
+func (testTokens) AuthenticateToken(token string) (string, error) {
+	if token == testToken {
+		return "test", nil
+	}
+	return "", userdb.ErrInvalidToken
+}
+
+// testMailer keeps the last email body for each recipient.
+type testMailer struct {
+	sync.Mutex
+	sent map[string][]byte
+}
+
+func (m *testMailer) Send(to, subject string, body []byte) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.sent == nil {
+		m.sent = make(map[string][]byte)
+	}
+	m.sent[to] = body
+	return nil
+}
+
+func (m *testMailer) reset(to string) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.sent, to)
+}
+
+func (m *testMailer) get(to string) []byte {
+	m.Lock()
+	defer m.Unlock()
+	return m.sent[to]
+}
+
+// testFlaky is a notifier which fails while Fail is set. Like an HTTP client, it returns the address in its error.
+type testFlaky struct {
+	sync.Mutex
+	Fail bool
+	sent int
+}
+
+func (f *testFlaky) ValidateAddress(addr string) (string, error) {
+	return addr, nil
+}
+
+func (f *testFlaky) Send(addr, subject, body string) error {
+	f.Lock()
+	defer f.Unlock()
+	if f.Fail {
+		return &url.Error{Op: "Post", URL: addr, Err: errors.New("flaky notifier failed")}
+	}
+	f.sent++
+	return nil
+}
+
+func (f *testFlaky) setFail(fail bool) {
+	f.Lock()
+	defer f.Unlock()
+	f.Fail = fail
+}
+
+// testShop runs the customer and staff handlers of a shop with the fixture catalog against a temporary database.
+type testShop struct {
+	t           *testing.T
+	shop        *Shop
+	mailer      *testMailer
+	notify      *notifytest.Server
+	flaky       *testFlaky
+	btcpay      *btcpaytest.Server
+	cust        *httptest.Server
+	staff       *httptest.Server
+	custClient  *http.Client
+	staffClient *http.Client
+}
+
+// newTestShop returns a testShop with an empty database and a staff client which is logged in. Everything is closed when the test ends.
+func newTestShop(t *testing.T) *testShop {
+	t.Helper()
+
+	database, err := db.OpenDB(filepath.Join(t.TempDir(), "digitalgoods.sqlite3"))
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	ts := &testShop{
+		t:      t,
+		btcpay: btcpaytest.NewServer(""),
+		mailer: &testMailer{},
+		notify: notifytest.NewServer(),
+		flaky:  &testFlaky{},
+	}
+	t.Cleanup(ts.btcpay.Close)
+	t.Cleanup(ts.notify.Close)
+
+	ts.shop = &Shop{
+		APITokens:        testTokens{},
+		Btcpay:           ts.btcpay.Store(),
+		BtcpayStatus:     ts.btcpay.Store().StatusDaemon(),
+		CustomerSessions: scs.New(),
+		Database:         database,
+		Emailer:          ts.mailer,
+		Langs:            lang.MakeLanguages(nil, "de", "en"),
+		Site:             html.DefaultSite,
+		StaffSessions:    scs.New(),
+		StaffUsers:       testUsers{},
+		VATRate: func(digitalgoods.Sale) (string, int) {
+			return "standard", 0
+		},
+		metrics: newShopMetrics(),
+	}
+	ts.shop.SetCatalog(testCatalog())
+	ts.shop.Notifiers = ts.notify.Notifiers()
+	ts.shop.Notifiers["email"] = notify.Email{Emailer: ts.mailer}
+	ts.shop.Notifiers["ntfysh"] = notify.Ntfysh{}
+	ts.shop.Notifiers["flaky"] = ts.flaky
+	ts.shop.Discounts = digitalgoods.Discounts{
+		{Code: "Test10", Percent: 10, Variants: []string{"voucher-10"}, MaxUses: 1},
+		{Code: "Expired", Amount: 100, ValidUntil: "2000-12-31"},
+		{Code: "Free", Percent: 100, Variants: []string{"voucher-10"}},
+	}
+	ts.shop.PaymentMethods = []payment.Method{
+		&payment.BTCPay{
+			Purchases:    ts.shop,
+			RedirectPath: "/by-cookie",
+			Store:        ts.btcpay.Store(),
+			ErrCreateInvoice: func(err error) http.Handler {
+				return ts.shop.frontendErr(fmt.Errorf("creating invoice: %w", err), "Error creating BTCPay invoice")
+			},
+			ErrWebhook: func(err error) http.Handler {
+				slog.Error("webhook error", "method", "btcpay", "err", err)
+				ts.shop.metrics.webhookErrors.Inc("btcpay")
+				return nil
+			},
+			GetStatus: ts.shop.BtcpayStatus,
+		},
+		payment.SEPA{
+			Purchases: ts.shop,
+		},
+	}
+
+	custHandler, err := ts.shop.CustomerHandler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.cust = httptest.NewServer(custHandler)
+	t.Cleanup(ts.cust.Close)
+	ts.btcpay.WebhookURL = ts.cust.URL + "/payment/btcpay/webhook"
+
+	staffHandler, err := ts.shop.StaffHandler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.staff = httptest.NewServer(staffHandler)
+	t.Cleanup(ts.staff.Close)
+
+	ts.custClient = newTestClient()
+	ts.staffClient = newTestClient()
+	ts.post(ts.staffClient, ts.staff.URL+"/login", url.Values{"username": {"staff"}, "password": {"staff"}})
+	return ts
+}
+
+// newTestClient returns a client which keeps cookies and does not follow redirects.
+func newTestClient() *http.Client {
+	jar, _ := cookiejar.New(nil)
+	return &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+		Jar:     jar,
+		Timeout: 10 * time.Second,
+	}
+}
+
+// post sends a form and expects a redirect. It returns the redirect location.
+func (ts *testShop) post(client *http.Client, target string, form url.Values) string {
+	ts.t.Helper()
+	resp, err := client.PostForm(target, form)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusSeeOther && resp.StatusCode != http.StatusFound {
+		body, _ := io.ReadAll(resp.Body)
+		ts.t.Fatalf("POST %s: got status %d, want redirect: %s", target, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp.Header.Get("Location")
+}
+
+// get expects status 200 and returns the response body.
+func (ts *testShop) get(client *http.Client, target string) string {
+	ts.t.Helper()
+	resp, err := client.Get(target)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		ts.t.Fatalf("GET %s: got status %d", target, resp.StatusCode)
+	}
+	return string(body)
+}
+
+// orderValues returns the form values of the customer order form. The keys of quantities are article ID + "-" + variant ID.
+func orderValues(quantities map[string]int) url.Values {
+	var form = url.Values{}
+	form.Set("area", "non-eu")
+	for key, quantity := range quantities {
+		form.Set(key, fmt.Sprint(quantity))
+	}
+	return form
+}
+
+// submitOrder posts the customer order form. If the order has been accepted, it returns the purchase. Else it returns the response body, which contains the order form and the error messages.
+func (ts *testShop) submitOrder(form url.Values) (*digitalgoods.Purchase, string) {
+	ts.t.Helper()
+	resp, err := ts.custClient.PostForm(ts.cust.URL+"/en", form)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSeeOther {
+		return nil, string(body)
+	}
+
+	// location is /en/order/id/access-key
+	location := resp.Header.Get("Location")
+	parts := strings.Split(strings.Trim(location, "/"), "/")
+	if len(parts) != 4 || parts[1] != "order" {
+		ts.t.Fatalf("unexpected redirect after order: %s", location)
+	}
+	purchase, err := ts.shop.Database.GetPurchaseByIDAndAccessKey(parts[2], parts[3])
+	if err != nil {
+		ts.t.Fatalf("getting purchase %s: %v", parts[2], err)
+	}
+	if purchase.Status != digitalgoods.StatusNew {
+		ts.t.Fatalf("new purchase has status %s", purchase.Status)
+	}
+	return purchase, ""
+}
+
+// order places an order through the customer order form and expects it to be accepted.
+func (ts *testShop) order(quantities map[string]int) *digitalgoods.Purchase {
+	ts.t.Helper()
+	return ts.orderForm(orderValues(quantities))
+}
+
+func (ts *testShop) orderForm(form url.Values) *digitalgoods.Purchase {
+	ts.t.Helper()
+	purchase, body := ts.submitOrder(form)
+	if purchase == nil {
+		ts.t.Fatalf("order %v has been rejected: %s", form, body)
+	}
+	return purchase
+}
+
+// rejectOrder submits the order form and expects it to be rejected with the given error message. No purchase must be inserted.
+func (ts *testShop) rejectOrder(form url.Values, want string) {
+	ts.t.Helper()
+	count := ts.countPurchases()
+	purchase, body := ts.submitOrder(form)
+	if purchase != nil {
+		ts.t.Fatalf("order %v has been accepted", form)
+	}
+	if !strings.Contains(body, stdhtml.EscapeString(want)) {
+		ts.t.Fatalf("order form does not show the error %q", want)
+	}
+	if got := ts.countPurchases(); got != count {
+		ts.t.Fatalf("rejected order: got %d purchases, want %d", got, count)
+	}
+}
+
+func (ts *testShop) countPurchases() int {
+	ts.t.Helper()
+	counts, _, err := ts.shop.Database.CountPurchases()
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	var total int
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
+
+func (ts *testShop) purchaseURL(purchase *digitalgoods.Purchase) string {
+	return ts.cust.URL + "/en/order/" + purchase.ID + "/" + purchase.AccessKey
+}
+
+// setNotify saves the contact of the purchase through the customer purchase page.
+func (ts *testShop) setNotify(purchase *digitalgoods.Purchase, form url.Values) string {
+	ts.t.Helper()
+	return ts.post(ts.custClient, ts.purchaseURL(purchase), form)
+}
+
+func (ts *testShop) upload(stockID string, codes ...string) {
+	ts.t.Helper()
+	ts.post(ts.staffClient, ts.staff.URL+"/upload/"+stockID, url.Values{"codes": {strings.Join(codes, "\n")}})
+}
+
+func (ts *testShop) markPaid(purchase *digitalgoods.Purchase) {
+	ts.t.Helper()
+	ts.post(ts.staffClient, ts.staff.URL+"/purchase/"+purchase.ID+"/mark-paid", url.Values{"id": {purchase.ID}, "confirm": {"on"}})
+}
+
+func (ts *testShop) deliverOutbox() {
+	ts.t.Helper()
+	if err := ts.shop.deliverOutbox(); err != nil {
+		ts.t.Fatal(err)
+	}
+}
+
+// reload gets the purchase from the database and checks its status.
+func (ts *testShop) reload(purchase *digitalgoods.Purchase, want digitalgoods.Status) *digitalgoods.Purchase {
+	ts.t.Helper()
+	purchase, err := ts.shop.Database.GetPurchaseByID(purchase.ID)
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	if purchase.Status != want {
+		ts.t.Fatalf("purchase %s has status %s, want %s", purchase.ID, purchase.Status, want)
+	}
+	return purchase
+}
+
+func (ts *testShop) stock() digitalgoods.Stock {
+	ts.t.Helper()
+	stock, err := ts.shop.Database.GetStock()
+	if err != nil {
+		ts.t.Fatal(err)
+	}
+	return stock
+}
+
+// checkDelivery checks that the purchase has not been over-delivered and that no payload has been delivered twice.
+func checkDelivery(t *testing.T, purchase *digitalgoods.Purchase) {
+	t.Helper()
+	unfulfilled, err := purchase.GetUnfulfilled()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range unfulfilled {
+		if row.Quantity < 0 {
+			t.Fatalf("purchase %s: variant %s over-delivered by %d", purchase.ID, row.VariantID, -row.Quantity)
+		}
+	}
+	var seen = make(map[string]bool)
+	for _, item := range purchase.Delivered {
+		if seen[item.Payload] {
+			t.Fatalf("purchase %s: payload %s delivered twice", purchase.ID, item.Payload)
+		}
+		seen[item.Payload] = true
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	ts := newTestShop(t)
+	ts.upload("voucher-5", "deliver-1", "deliver-2")
+
+	purchase := ts.order(map[string]int{"voucher-voucher-5": 2})
+	if sum := purchase.Ordered.Sum(); sum != 1000 {
+		t.Fatalf("got sum %d, want 1000", sum)
+	}
+
+	// customer page and cookie
+	ts.get(ts.custClient, ts.purchaseURL(purchase))
+	location := ts.setNotify(purchase, url.Values{"notify-proto": {"email"}, "notify-addr": {"customer@example.com"}})
+	if !strings.HasSuffix(location, "#notify") {
+		t.Fatalf("unexpected redirect after saving notify address: %s", location)
+	}
+
+	// payment processing, as called by the btcpay webhook
+	if err := ts.shop.SetPurchaseProcessing(purchase.ID, purchase.PaymentKey); err != nil {
+		t.Fatal(err)
+	}
+	ts.reload(purchase, digitalgoods.StatusPaymentProcessing)
+
+	// settlement, as called by the btcpay webhook
+	if err := ts.shop.SetPurchasePaid(purchase.ID, purchase.PaymentKey, "btcpay"); err != nil {
+		t.Fatal(err)
+	}
+	ts.deliverOutbox()
+	purchase = ts.reload(purchase, digitalgoods.StatusFinalized)
+	if purchase.NotifyAddr != "" {
+		t.Fatal("notify address has not been deleted after delivery")
+	}
+	checkDelivery(t, purchase)
+
+	body := ts.get(ts.custClient, ts.purchaseURL(purchase))
+	for _, code := range []string{"deliver-1", "deliver-2"} {
+		if !strings.Contains(body, code) {
+			t.Fatalf("purchase page does not contain code %s", code)
+		}
+	}
+	if strings.Contains(body, "data:image/png") {
+		t.Fatal("purchase page contains inline QR codes")
+	}
+
+	// QR codes are served on demand
+	for _, name := range []string{"qr-voucher-5-0.png", "qr-voucher-5-1.png"} {
+		if !strings.Contains(body, "/"+name) {
+			t.Fatalf("purchase page does not link QR code %s", name)
+		}
+		if png := ts.get(ts.custClient, ts.purchaseURL(purchase)+"/"+name); !strings.HasPrefix(png, "\x89PNG") {
+			t.Fatalf("QR code %s is not a png image", name)
+		}
+	}
+	for _, target := range []string{
+		ts.purchaseURL(purchase) + "/qr-voucher-5-2.png",
+		ts.purchaseURL(purchase) + "/qr-voucher-10-0.png",
+		ts.cust.URL + "/en/order/" + purchase.ID + "/wrong-key/qr-voucher-5-0.png",
+	} {
+		resp, err := ts.custClient.Get(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("GET %s: got status %d, want %d", target, resp.StatusCode, http.StatusNotFound)
+		}
+	}
+
+	// downloads
+	for _, format := range []string{"txt", "csv"} {
+		body := ts.get(ts.custClient, ts.purchaseURL(purchase)+"/codes."+format)
+		if !strings.Contains(body, "deliver-1") || !strings.Contains(body, "deliver-2") {
+			t.Fatalf("%s download does not contain the codes", format)
+		}
+	}
+	if body := ts.get(ts.custClient, ts.purchaseURL(purchase)+"/codes.pdf"); !strings.HasPrefix(body, "%PDF-") {
+		t.Fatal("pdf download is not a pdf document")
+	}
+
+	// settling again must not change anything
+	if err := ts.shop.SetPurchasePaid(purchase.ID, purchase.PaymentKey, "btcpay"); err != nil {
+		t.Fatal(err)
+	}
+	again := ts.reload(purchase, digitalgoods.StatusFinalized)
+	if len(again.Delivered) != len(purchase.Delivered) {
+		t.Fatalf("settling twice delivered %d instead of %d items", len(again.Delivered), len(purchase.Delivered))
+	}
+}
+
+func TestDiscount(t *testing.T) {
+	ts := newTestShop(t)
+
+	// rejected codes re-render the order form, a sum of zero can't be invoiced
+	for code, want := range map[string]string{
+		"unknown": "This discount code is not valid.",
+		"expired": "This discount code has expired or is not valid yet.",
+		"free":    "This discount code can't be used for this order.",
+	} {
+		form := orderValues(map[string]int{"voucher-voucher-10": 1})
+		form.Set("discount-code", code)
+		ts.rejectOrder(form, want)
+	}
+
+	ts.upload("voucher-5", "discount-1")
+	ts.upload("voucher-10", "discount-2", "discount-3")
+
+	form := orderValues(map[string]int{"voucher-voucher-5": 1, "voucher-voucher-10": 2})
+	form.Set("discount-code", " test10 ")
+	purchase := ts.orderForm(form)
+	if sum := purchase.Ordered.Sum(); sum != 500+2*900 {
+		t.Fatalf("got sum %d, want %d", sum, 500+2*900)
+	}
+	for _, row := range purchase.Ordered {
+		if row.VariantID == "voucher-10" && (row.ListPrice != 1000 || row.Discount != "Test10") {
+			t.Fatalf("discount has not been recorded: %+v", row)
+		}
+		if row.VariantID == "voucher-5" && (row.ListPrice != 0 || row.Discount != "") {
+			t.Fatalf("discount has been applied outside of its scope: %+v", row)
+		}
+	}
+
+	// usage limit
+	form.Set("discount-code", "TEST10")
+	ts.rejectOrder(form, "This discount code has been used up.")
+
+	// the sales tax log contains the charged amount
+	ts.markPaid(purchase)
+	ts.reload(purchase, digitalgoods.StatusFinalized)
+	sales, err := ts.shop.Database.GetSales(purchase.CreateDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gross int
+	for _, sale := range sales {
+		if sale.ID == purchase.ID {
+			gross += sale.GrossSum
+		}
+	}
+	if gross != purchase.Ordered.Sum() {
+		t.Fatalf("sales tax log contains %d, want %d", gross, purchase.Ordered.Sum())
+	}
+}
+
+func TestVolumePricing(t *testing.T) {
+	ts := newTestShop(t)
+	for quantity, want := range map[int]int{9: 9 * 500, 10: 10 * 450, 49: 49 * 450, 50: 50 * 400} {
+		purchase := ts.order(map[string]int{"voucher-voucher-5": quantity})
+		if sum := purchase.Ordered.Sum(); sum != want {
+			t.Errorf("quantity %d: got sum %d, want %d", quantity, sum, want)
+		}
+	}
+
+	// tiers are shown in ascending order, although the fixture lists them in descending order
+	body := ts.get(ts.custClient, ts.cust.URL+"/en")
+	if i, j := strings.Index(body, `"text-nowrap">10: `), strings.Index(body, `"text-nowrap">50: `); i < 0 || j < 0 || i > j {
+		t.Errorf("tiers are not shown in ascending order")
+	}
+}
+
+func TestQuantityLimits(t *testing.T) {
+	ts := newTestShop(t)
+
+	// maximum quantity of variant
+	ts.order(map[string]int{"voucher-voucher-10": 10})
+	ts.rejectOrder(orderValues(map[string]int{"voucher-voucher-10": 11}), "Maximum quantity per order: 10")
+
+	// maximum quantity of purchase
+	ts.shop.MaxQuantity = 5
+	ts.rejectOrder(orderValues(map[string]int{"voucher-voucher-5": 3, "voucher-voucher-10": 3}), "Maximum number of items per order: 5")
+	ts.shop.MaxQuantity = 0
+
+	// stock, variants share their stock
+	ts.upload("shared", "limit-1", "limit-2", "limit-3")
+	ts.shop.LimitToStock = true
+	ts.rejectOrder(orderValues(map[string]int{"shared-shared-a": 1, "shared-shared-b": 3}), "Not enough in stock. Available: 3")
+	ts.order(map[string]int{"shared-shared-b": 3})
+}
+
+func TestMyOrders(t *testing.T) {
+	ts := newTestShop(t)
+	first := ts.order(map[string]int{"voucher-voucher-5": 1})
+	second := ts.order(map[string]int{"voucher-voucher-10": 1})
+
+	body := ts.get(ts.custClient, ts.cust.URL+"/en/orders")
+	for _, purchase := range []*digitalgoods.Purchase{first, second} {
+		if !strings.Contains(body, "/en/order/"+purchase.ID+"/"+purchase.AccessKey) {
+			t.Fatalf("my orders page does not contain purchase %s", purchase.ID)
+		}
+	}
+
+	// other sessions must not see them
+	if body := ts.get(newTestClient(), ts.cust.URL+"/en/orders"); strings.Contains(body, first.AccessKey) {
+		t.Fatal("my orders page of a new session contains a purchase")
+	}
+}
+
+func TestUnderdelivered(t *testing.T) {
+	ts := newTestShop(t)
+	ts.upload("voucher-10", "under-1")
+
+	purchase := ts.order(map[string]int{"voucher-voucher-10": 3})
+	ts.markPaid(purchase)
+	purchase = ts.reload(purchase, digitalgoods.StatusUnderdelivered)
+	if len(purchase.Delivered) != 1 {
+		t.Fatalf("got %d delivered items, want 1", len(purchase.Delivered))
+	}
+	if purchase.DeleteDate != "" {
+		t.Fatal("underdelivered purchase has a deletion date")
+	}
+
+	// partial upload
+	ts.upload("voucher-10", "under-2")
+	purchase = ts.reload(purchase, digitalgoods.StatusUnderdelivered)
+	if len(purchase.Delivered) != 2 {
+		t.Fatalf("got %d delivered items, want 2", len(purchase.Delivered))
+	}
+
+	// upload triggers fulfilment
+	ts.upload("voucher-10", "under-3", "under-4")
+	purchase = ts.reload(purchase, digitalgoods.StatusFinalized)
+	checkDelivery(t, purchase)
+	if stock := ts.stock(); stock["voucher-10"] != 1 {
+		t.Fatalf("got stock %d, want 1", stock["voucher-10"])
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	ts := newTestShop(t)
+
+	purchase := ts.order(map[string]int{"voucher-voucher-10": 1})
+	// saving the contact on the German page sets the language
+	ts.post(ts.custClient, ts.cust.URL+"/de/order/"+purchase.ID+"/"+purchase.AccessKey, url.Values{"notify-proto": {"email"}, "notify-addr": {"notify@example.com"}})
+	purchase = ts.reload(purchase, digitalgoods.StatusNew)
+	if purchase.Lang != "de" {
+		t.Fatalf("got language %q, want de", purchase.Lang)
+	}
+
+	var events = []struct {
+		name string
+		run  func()
+	}{
+		{"processing", func() {
+			if err := ts.shop.SetPurchaseProcessing(purchase.ID, purchase.PaymentKey); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"staff message", func() {
+			ts.post(ts.staffClient, ts.staff.URL+"/purchase/"+purchase.ID+"/message", url.Values{"id": {purchase.ID}, "message": {"Hello"}})
+		}},
+		{"underdelivered", func() { ts.markPaid(purchase) }},
+		{"remaining delivered", func() { ts.upload("voucher-10", "notify-1") }},
+	}
+	for _, event := range events {
+		ts.mailer.reset("notify@example.com")
+		event.run()
+		ts.deliverOutbox()
+		body := ts.mailer.get("notify@example.com")
+		if len(body) == 0 {
+			t.Fatalf("%s: no email has been sent", event.name)
+		}
+		if bytes.Contains(body, []byte(purchase.AccessKey)) || bytes.Contains(body, []byte("notify-1")) {
+			t.Fatalf("%s: email contains the access key or a code", event.name)
+		}
+	}
+
+	purchase = ts.reload(purchase, digitalgoods.StatusFinalized)
+	if purchase.NotifyAddr != "" {
+		t.Fatal("notify address has not been deleted after delivery")
+	}
+}
+
+func TestNotificationChannels(t *testing.T) {
+	ts := newTestShop(t)
+
+	var channels = []struct {
+		proto string
+		addr  string
+		want  string // normalized address, empty if invalid
+	}{
+		{"matrix", "@customer:example.org", "@customer:example.org"},
+		{"matrix", "customer", ""},
+		{"signal", "0049 151 2345678", "+491512345678"},
+		{"signal", "12345", ""},
+		{"webhook", ts.notify.WebhookURL("customer"), ts.notify.WebhookURL("customer")},
+		{"webhook", "http://example.org/hook", ""},
+		{"xmpp", "xmpp:Customer@example.org", "customer@example.org"},
+		{"xmpp", "customer@example.org/resource", ""},
+		{"openpgp", "customer", ""},
+	}
+	for _, channel := range channels {
+		purchase := ts.order(map[string]int{"voucher-voucher-5": 1})
+		ts.setNotify(purchase, url.Values{"notify-proto": {channel.proto}, "notify-addr": {channel.addr}})
+		purchase = ts.reload(purchase, digitalgoods.StatusNew)
+		if purchase.NotifyAddr != channel.want {
+			t.Fatalf("%s address %q: got %q, want %q", channel.proto, channel.addr, purchase.NotifyAddr, channel.want)
+		}
+
+		if err := ts.shop.SetPurchaseProcessing(purchase.ID, purchase.PaymentKey); err != nil {
+			t.Fatalf("%s: %v", channel.proto, err)
+		}
+		if channel.want == "" {
+			// nothing is enqueued for an invalid address
+			if purchase.NotifyProto != "" || purchase.NotifyKey != "" {
+				t.Fatalf("%s address %q: got proto %q and key %q, want empty", channel.proto, channel.addr, purchase.NotifyProto, purchase.NotifyKey)
+			}
+			due, err := ts.shop.Database.GetDueNotifications(time.Now().Unix(), 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, entry := range due {
+				if entry.PurchaseID == purchase.ID {
+					t.Fatalf("%s address %q: notification has been enqueued", channel.proto, channel.addr)
+				}
+			}
+			continue
+		}
+		ts.deliverOutbox()
+		var received bool
+		for range 50 { // the xmpp test server records messages asynchronously
+			for _, message := range ts.notify.Messages() {
+				if message.Proto == channel.proto && message.Addr == channel.want && strings.Contains(message.Text, "Your payment has been registered") {
+					if strings.Contains(message.Text, purchase.ID) {
+						t.Fatalf("%s: notification contains the order number", channel.proto)
+					}
+					received = true
+				}
+			}
+			if received {
+				break
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		if !received {
+			t.Fatalf("%s: no notification has been received", channel.proto)
+		}
+	}
+}
+
+// TestProductFeed checks the availability and the localized fields of the feed in two languages, and fetches the feeds.
+func TestProductFeed(t *testing.T) {
+	ts := newTestShop(t)
+	ts.shop.BaseURL = "https://shop.example.com"
+	ts.upload("voucher-5", "feed-1")
+
+	for _, l := range ts.shop.Langs {
+		feed, err := ts.shop.makeFeed(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var about = map[string]string{"de": "Ein Testgutschein", "en": "A test voucher"}[l.Prefix]
+		var availability = make(map[string]string)
+		for _, product := range feed.Products {
+			availability[product.Id] = product.Availability
+			if product.ItemGroupId == "voucher" && product.Description != about {
+				t.Errorf("%s %s: got description %q, want %q", l.Prefix, product.Id, product.Description, about)
+			}
+			if want := "https://shop.example.com/" + l.Prefix + "#" + product.ItemGroupId; product.Link != want {
+				t.Errorf("%s %s: got link %q, want %q", l.Prefix, product.Id, product.Link, want)
+			}
+		}
+		if availability["voucher-5"] != "in stock" || availability["voucher-10"] != "out of stock" {
+			t.Errorf("%s: got availability %v", l.Prefix, availability)
+		}
+	}
+
+	for _, path := range []string{"/de/productfeed.xml", "/en/productfeed.xml", "/productfeed.xml"} {
+		resp, err := ts.custClient.Get(ts.cust.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/xml") {
+			t.Fatalf("%s: got status %d and content type %q", path, resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+	}
+}
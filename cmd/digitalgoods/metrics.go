@@ -0,0 +1,150 @@
+package main
+
+import (
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/digitalgoods/metrics"
+	"github.com/julienschmidt/httprouter"
+)
+
+// shopMetrics are the counters and histograms of a storefront. Each storefront has its own staff listener, so it serves its own registry.
+type shopMetrics struct {
+	registry             *metrics.Registry
+	httpDuration         *metrics.HistogramVec
+	notificationFailures *metrics.CounterVec
+	webhookErrors        *metrics.CounterVec
+}
+
+func newShopMetrics() *shopMetrics {
+	registry := &metrics.Registry{}
+	return &shopMetrics{
+		registry:             registry,
+		httpDuration:         registry.NewHistogramVec("digitalgoods_http_request_duration_seconds", "Duration of HTTP requests by listener and route.", metrics.DefaultBuckets, "listener", "method", "route"),
+		notificationFailures: registry.NewCounterVec("digitalgoods_notification_failures_total", "Failed attempts to deliver a notification, by event.", "event"),
+		webhookErrors:        registry.NewCounterVec("digitalgoods_webhook_errors_total", "Errors while processing payment webhooks, by payment method.", "method"),
+	}
+}
+
+// router is a httprouter.Router which records the request duration of each route in a histogram and logs it at debug level. Routes are logged instead of paths, which can contain access keys.
+type router struct {
+	*httprouter.Router
+	duration *metrics.HistogramVec
+	listener string
+}
+
+func newRouter(listener string, duration *metrics.HistogramVec) *router {
+	return &router{
+		Router:   httprouter.New(),
+		duration: duration,
+		listener: listener,
+	}
+}
+
+func (rtr *router) Handler(method, path string, handler http.Handler) {
+	rtr.Router.Handler(method, path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		handler.ServeHTTP(w, r)
+		duration := time.Since(start)
+		rtr.duration.Observe(duration.Seconds(), rtr.listener, method, path)
+		slog.DebugContext(r.Context(), "request", "listener", rtr.listener, "method", method, "route", path, "duration", duration)
+	}))
+}
+
+func (rtr *router) HandlerFunc(method, path string, handler http.HandlerFunc) {
+	rtr.Handler(method, path, handler)
+}
+
+// staffMetricsGet serves metrics in the Prometheus text format. Requests are authenticated with an API token. The counters and histograms are kept in memory, the other values are read from the database.
+func (s *Shop) staffMetricsGet(w http.ResponseWriter, r *http.Request) error {
+	if _, err := s.authenticateToken(r); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return nil
+	}
+
+	counts, oldest, err := s.Database.CountPurchases()
+	if err != nil {
+		return err
+	}
+	stock, err := s.Database.GetStock()
+	if err != nil {
+		return err
+	}
+	underdelivered, err := s.getUnderdelivered()
+	if err != nil {
+		return err
+	}
+	delivered, err := s.Database.CountDelivered()
+	if err != nil {
+		return err
+	}
+	total, failing, dead, err := s.Database.CountNotifications()
+	if err != nil {
+		return err
+	}
+
+	var statuses = []digitalgoods.Status{digitalgoods.StatusNew, digitalgoods.StatusPaymentProcessing, digitalgoods.StatusUnderdelivered, digitalgoods.StatusFinalized}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	metrics.WriteHeader(w, "digitalgoods_purchases", "Number of purchases by status.", "gauge")
+	for _, status := range statuses {
+		metrics.WriteSample(w, "digitalgoods_purchases", label("status", string(status)), float64(counts[status]))
+	}
+
+	metrics.WriteHeader(w, "digitalgoods_purchase_oldest_create_timestamp_seconds", "Creation date of the oldest purchase by status, as unix timestamp. Missing if there is no purchase with that status.", "gauge")
+	for _, status := range statuses {
+		if date, err := time.Parse(time.DateOnly, oldest[status]); err == nil {
+			metrics.WriteSample(w, "digitalgoods_purchase_oldest_create_timestamp_seconds", label("status", string(status)), float64(date.Unix()))
+		}
+	}
+
+	var stockIDs []string
+	var warnLevels = make(map[string]int)
+	for _, brand := range s.uploadCatalog {
+		for _, unit := range brand.Units {
+			stockIDs = append(stockIDs, unit.StockID)
+			for _, variant := range unit.Variants {
+				warnLevels[unit.StockID] = max(warnLevels[unit.StockID], variant.WarnStock)
+			}
+		}
+	}
+	slices.Sort(stockIDs)
+
+	metrics.WriteHeader(w, "digitalgoods_stock", "Number of items in stock by stock unit.", "gauge")
+	for _, stockID := range stockIDs {
+		metrics.WriteSample(w, "digitalgoods_stock", label("stock", stockID), float64(stock[stockID]))
+	}
+
+	metrics.WriteHeader(w, "digitalgoods_stock_warn_level", "Stock level below which staff should restock, by stock unit. Zero if not configured.", "gauge")
+	for _, stockID := range stockIDs {
+		metrics.WriteSample(w, "digitalgoods_stock_warn_level", label("stock", stockID), float64(warnLevels[stockID]))
+	}
+
+	metrics.WriteHeader(w, "digitalgoods_underdelivered_items", "Number of items which have been paid but not delivered yet, by stock unit.", "gauge")
+	for _, stockID := range stockIDs {
+		metrics.WriteSample(w, "digitalgoods_underdelivered_items", label("stock", stockID), float64(underdelivered[stockID]))
+	}
+
+	metrics.WriteHeader(w, "digitalgoods_delivered_items_total", "Number of delivered items by variant, according to the sales tax log.", "counter")
+	for _, variantID := range slices.Sorted(maps.Keys(delivered)) {
+		metrics.WriteSample(w, "digitalgoods_delivered_items_total", label("variant", variantID), float64(delivered[variantID]))
+	}
+
+	metrics.WriteHeader(w, "digitalgoods_notifications", "Number of notifications in the outbox by state.", "gauge")
+	metrics.WriteSample(w, "digitalgoods_notifications", label("state", "pending"), float64(total-failing-dead))
+	metrics.WriteSample(w, "digitalgoods_notifications", label("state", "failing"), float64(failing))
+	metrics.WriteSample(w, "digitalgoods_notifications", label("state", "dead"), float64(dead))
+
+	s.metrics.registry.Write(w)
+	return nil
+}
+
+func label(name, value string) []metrics.Label {
+	return []metrics.Label{{Name: name, Value: value}}
+}
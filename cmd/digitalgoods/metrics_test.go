@@ -0,0 +1,64 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	ts := newTestShop(t)
+
+	resp, err := http.Get(ts.staff.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("metrics without token: got status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	// an underdelivered purchase with a failing notification
+	ts.upload("voucher-5", "metrics-1")
+	purchase := ts.order(map[string]int{"voucher-voucher-5": 2})
+	ts.setNotify(purchase, url.Values{"notify-proto": {"flaky"}, "notify-addr": {"flaky"}})
+	ts.flaky.setFail(true)
+	ts.markPaid(purchase)
+	ts.deliverOutbox()
+
+	req, err := http.NewRequest(http.MethodGet, ts.staff.URL+"/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("metrics: got status %d", resp.StatusCode)
+	}
+
+	for _, want := range []string{
+		`digitalgoods_purchases{status="underdelivered"} 1` + "\n",
+		`digitalgoods_purchase_oldest_create_timestamp_seconds{status="underdelivered"} `,
+		`digitalgoods_stock{stock="shared"} 0` + "\n",
+		`digitalgoods_stock_warn_level{stock="voucher-10"} 5` + "\n",
+		`digitalgoods_underdelivered_items{stock="voucher-5"} 1` + "\n",
+		`digitalgoods_delivered_items_total{variant="voucher-5"} 1` + "\n",
+		`digitalgoods_notifications{state="failing"} 1` + "\n",
+		`digitalgoods_notification_failures_total{event="underdelivered"} `,
+		`digitalgoods_http_request_duration_seconds_count{listener="customer",method="POST",route="/en"} `,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+var errMultipleKeys = errors.New("more than one key")
+
+// validatePublicKey checks that key is exactly one armored OpenPGP public key which can be used for encryption.
+func validatePublicKey(key string) error {
+	_, err := encryptTo(key, nil)
+	return err
+}
+
+// encryptTo encrypts plaintext to the armored OpenPGP public key and returns an armored message. The key must contain exactly one entity, so it is clear who can read the message.
+func encryptTo(key string, plaintext []byte) (string, error) {
+	if strings.Count(key, "-----BEGIN PGP") > 1 {
+		return "", errMultipleKeys // ReadArmoredKeyRing would read the first block only
+	}
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case len(entities) == 0:
+		return "", errors.New("no key found")
+	case len(entities) > 1:
+		return "", errMultipleKeys
+	}
+	if _, ok := entities[0].EncryptionKey(time.Now()); !ok {
+		return "", errors.New("no valid encryption key")
+	}
+
+	var buf bytes.Buffer
+	armored, err := armor.Encode(&buf, "PGP MESSAGE", nil)
+	if err != nil {
+		return "", err
+	}
+	w, err := openpgp.Encrypt(armored, entities, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	if err := armored.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
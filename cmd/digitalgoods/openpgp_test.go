@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/dys2p/digitalgoods"
+)
+
+// newTestKey returns a new OpenPGP entity and its armored public key.
+func newTestKey(t *testing.T, address string) (*openpgp.Entity, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Customer", "", address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key bytes.Buffer
+	armored, err := armor.Encode(&key, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(armored); err != nil {
+		t.Fatal(err)
+	}
+	armored.Close()
+	return entity, key.String()
+}
+
+func decrypt(t *testing.T, entity *openpgp.Entity, message []byte) []byte {
+	t.Helper()
+	block, err := armor.Decode(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("reading email: %v", err)
+	}
+	md, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("decrypting email: %v", err)
+	}
+	plaintext, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plaintext
+}
+
+func TestOpenPGP(t *testing.T) {
+	ts := newTestShop(t)
+	ts.upload("voucher-10", "pgp-1")
+	entity, key := newTestKey(t, "pgp@example.com")
+
+	purchase := ts.order(map[string]int{"voucher-voucher-10": 2})
+
+	// unusable keys are rejected with an error message, nothing is saved
+	_, other := newTestKey(t, "other@example.com")
+	for key, want := range map[string]string{
+		"no key":           "This OpenPGP public key can&#39;t be used for encryption.",
+		key + "\n" + other: "Please paste exactly one OpenPGP public key.",
+	} {
+		resp, err := ts.custClient.PostForm(ts.purchaseURL(purchase), url.Values{"notify-proto": {"openpgp"}, "notify-addr": {"pgp@example.com"}, "notify-key": {key}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !bytes.Contains(body, []byte(want)) {
+			t.Fatalf("got status %d, want the purchase page with the error %q", resp.StatusCode, want)
+		}
+		if purchase = ts.reload(purchase, digitalgoods.StatusNew); purchase.NotifyProto != "" {
+			t.Fatalf("unusable key has been saved, got proto %q", purchase.NotifyProto)
+		}
+	}
+
+	ts.setNotify(purchase, url.Values{"notify-proto": {"email"}, "notify-addr": {"pgp@example.com"}, "notify-key": {key}})
+	purchase = ts.reload(purchase, digitalgoods.StatusNew)
+	if purchase.NotifyProto != "openpgp" || purchase.NotifyKey == "" {
+		t.Fatalf("openpgp key has not been saved, got proto %q", purchase.NotifyProto)
+	}
+
+	// codes are sent when all of them have been delivered
+	ts.markPaid(purchase)
+	ts.deliverOutbox()
+	purchase = ts.reload(purchase, digitalgoods.StatusUnderdelivered)
+	plaintext := decrypt(t, entity, ts.mailer.get("pgp@example.com"))
+	for _, item := range purchase.Delivered {
+		if bytes.Contains(plaintext, []byte(item.Payload)) {
+			t.Fatal("email contains codes before all of them have been delivered")
+		}
+	}
+
+	ts.upload("voucher-10", "pgp-2")
+
+	// a late notification about an earlier event neither contains the codes nor deletes the contact
+	purchase = ts.reload(purchase, digitalgoods.StatusFinalized)
+	if err := ts.shop.notify(purchase, digitalgoods.EventProcessing); err != nil {
+		t.Fatal(err)
+	}
+	plaintext = decrypt(t, entity, ts.mailer.get("pgp@example.com"))
+	for _, item := range purchase.Delivered {
+		if bytes.Contains(plaintext, []byte(item.Payload)) {
+			t.Fatal("email about an earlier event contains codes")
+		}
+	}
+	if purchase = ts.reload(purchase, digitalgoods.StatusFinalized); purchase.NotifyKey == "" {
+		t.Fatal("notify key has been deleted before the delivery notification")
+	}
+
+	ts.deliverOutbox()
+	purchase = ts.reload(purchase, digitalgoods.StatusFinalized)
+	if purchase.NotifyAddr != "" || purchase.NotifyKey != "" {
+		t.Fatal("notify address and key have not been deleted after delivery")
+	}
+	plaintext = decrypt(t, entity, ts.mailer.get("pgp@example.com"))
+	for _, item := range purchase.Delivered {
+		if !bytes.Contains(plaintext, []byte(item.Payload)) {
+			t.Fatalf("email does not contain code %s", item.Payload)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/digitalgoods/html"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	outboxBatch       = 100
+	outboxInterval    = time.Minute
+	outboxMaxAttempts = 10 // about 17 hours with outboxBackoff
+)
+
+// outboxBackoff returns the delay after the given number of failed attempts: 1, 2, 4, ... minutes, at most eight hours.
+func outboxBackoff(attempts int) time.Duration {
+	return min(time.Minute<<min(attempts-1, 9), 8*time.Hour)
+}
+
+// runOutbox delivers due notifications every outboxInterval, or when it is woken up by wakeOutbox.
+func (s *Shop) runOutbox(wg *sync.WaitGroup) {
+	for {
+		wg.Add(1)
+		if err := s.deliverOutbox(); err != nil {
+			slog.Error("error delivering notifications", "err", err)
+		}
+		wg.Done()
+
+		select {
+		case <-s.outboxWake:
+		case <-time.After(outboxInterval):
+		}
+	}
+}
+
+// wakeOutbox makes runOutbox deliver due notifications now. It does not block.
+func (s *Shop) wakeOutbox() {
+	select {
+	case s.outboxWake <- struct{}{}:
+	default: // already woken up, or runOutbox is not running
+	}
+}
+
+// deliverOutbox sends the due notifications. Failed notifications are retried with backoff and given up after outboxMaxAttempts.
+func (s *Shop) deliverOutbox() error {
+	entries, err := s.Database.GetDueNotifications(time.Now().Unix(), outboxBatch)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		err := s.deliverNotification(entry)
+		if err == nil {
+			if err := s.Database.DeleteNotification(entry.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		s.metrics.notificationFailures.Inc(string(entry.Event))
+		attempts := entry.Attempts + 1
+		dead := attempts >= outboxMaxAttempts
+		slog.Warn("notification failed", "purchase", entry.PurchaseID, "notification", entry.ID, "event", entry.Event, "attempt", attempts, "dead", dead, "err", err)
+		if err := s.Database.FailNotification(entry.ID, err.Error(), time.Now().Add(outboxBackoff(attempts)).Unix(), dead); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Shop) deliverNotification(entry digitalgoods.OutboxEntry) error {
+	purchase, err := s.Database.GetPurchaseByID(entry.PurchaseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // purchase has been deleted
+	}
+	if err != nil {
+		return err
+	}
+	return s.notify(purchase, entry.Event)
+}
+
+func (s *Shop) staffOutboxGet(w http.ResponseWriter, r *http.Request) error {
+	entries, err := s.Database.GetFailedNotifications()
+	if err != nil {
+		return err
+	}
+	return html.StaffOutbox.Execute(w, struct {
+		Entries     []digitalgoods.OutboxEntry
+		MaxAttempts int
+	}{
+		Entries:     entries,
+		MaxAttempts: outboxMaxAttempts,
+	})
+}
+
+// staffOutboxRetryPost makes a failed notification due immediately.
+func (s *Shop) staffOutboxRetryPost(w http.ResponseWriter, r *http.Request) error {
+	id, err := strconv.ParseInt(httprouter.ParamsFromContext(r.Context()).ByName("id"), 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := s.Database.RetryNotification(id); err != nil {
+		return err
+	}
+	s.wakeOutbox()
+	http.Redirect(w, r, "/outbox", http.StatusSeeOther)
+	return nil
+}
+
+func (s *Shop) staffOutboxDeletePost(w http.ResponseWriter, r *http.Request) error {
+	id, err := strconv.ParseInt(httprouter.ParamsFromContext(r.Context()).ByName("id"), 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := s.Database.DeleteNotification(id); err != nil {
+		return err
+	}
+	http.Redirect(w, r, "/outbox", http.StatusSeeOther)
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+)
+
+func TestOutbox(t *testing.T) {
+	ts := newTestShop(t)
+
+	purchase := ts.order(map[string]int{"voucher-voucher-5": 1})
+	const addr = "https://hooks.example.com/customer-secret"
+	ts.setNotify(purchase, url.Values{"notify-proto": {"flaky"}, "notify-addr": {addr}})
+
+	// failing notifications don't affect the status change
+	ts.flaky.setFail(true)
+	if err := ts.shop.SetPurchaseProcessing(purchase.ID, purchase.PaymentKey); err != nil {
+		t.Fatal(err)
+	}
+	ts.reload(purchase, digitalgoods.StatusPaymentProcessing)
+
+	// retry until dead
+	var entry digitalgoods.OutboxEntry
+	for attempt := 1; attempt <= outboxMaxAttempts; attempt++ {
+		ts.deliverOutbox()
+		failed, err := ts.shop.Database.GetFailedNotifications()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(failed) != 1 || failed[0].PurchaseID != purchase.ID || failed[0].Attempts != attempt {
+			t.Fatalf("attempt %d: unexpected failed notifications: %+v", attempt, failed)
+		}
+		entry = failed[0]
+		if entry.Dead != (attempt == outboxMaxAttempts) {
+			t.Fatalf("attempt %d: got dead %t", attempt, entry.Dead)
+		}
+		if entry.Dead {
+			break
+		}
+		if entry.NextTry <= time.Now().Unix() {
+			t.Fatalf("attempt %d: notification has not been delayed", attempt)
+		}
+		if err := ts.shop.Database.RetryNotification(entry.ID); err != nil { // skip backoff
+			t.Fatal(err)
+		}
+	}
+
+	// staff view and retry
+	body := ts.get(ts.staffClient, ts.staff.URL+"/outbox")
+	if !strings.Contains(body, purchase.ID) || !strings.Contains(body, "flaky notifier failed") {
+		t.Fatal("staff outbox view does not contain the failed notification")
+	}
+	if strings.Contains(body, "customer-secret") || strings.Contains(entry.LastError, "customer-secret") {
+		t.Fatalf("notification error contains the address: %s", entry.LastError)
+	}
+	ts.flaky.setFail(false)
+	ts.post(ts.staffClient, fmt.Sprintf("%s/outbox/%d/retry", ts.staff.URL, entry.ID), nil)
+	ts.deliverOutbox()
+	if failed, err := ts.shop.Database.GetFailedNotifications(); err != nil {
+		t.Fatal(err)
+	} else if len(failed) != 0 {
+		t.Fatalf("notification has not been delivered after retry: %+v", failed)
+	}
+	ts.flaky.Lock()
+	defer ts.flaky.Unlock()
+	if ts.flaky.sent != 1 {
+		t.Fatalf("flaky notifier sent %d messages, want 1", ts.flaky.sent)
+	}
+}
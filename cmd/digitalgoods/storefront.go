@@ -0,0 +1,247 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dys2p/digitalgoods/btcpaytest"
+	"github.com/dys2p/digitalgoods/html"
+	"github.com/dys2p/digitalgoods/notify"
+	"github.com/dys2p/eco/email"
+	"github.com/dys2p/eco/httputil"
+	"github.com/dys2p/eco/lang"
+	"github.com/dys2p/eco/ntfysh"
+	"github.com/dys2p/eco/payment"
+	"github.com/dys2p/go-btcpay"
+)
+
+// newStorefront returns a copy of shared which is configured for the storefront. The database, sessions, staff users and API tokens are shared. In test mode, it uses a dummy emailer and a BTCPay Server stand-in, which is closed by the returned function.
+func newStorefront(shared Shop, storefront StorefrontConfig, localCustomerURL string, test bool) (*Shop, func(), error) {
+	s := &shared
+	s.BaseURL = storefront.BaseURL
+	s.Database = shared.Database.Shop(storefront.ID)
+	s.EmailFrom = storefront.EmailFrom
+	s.Hosts = storefront.AllHosts()
+	s.ID = storefront.ID
+	s.metrics = newShopMetrics()
+	s.NtfyshTopic = storefront.NtfyshTopic
+	s.StaffAddr = storefront.StaffAddr
+	s.StockBuckets = storefront.StockBuckets
+	s.Title = cmp.Or(storefront.Title, s.Hosts[0])
+	s.VATRate = storefront.VAT.Rate
+
+	var noop = func() {}
+
+	// catalog
+	catalog, err := storefront.LoadCatalog(shared.Catalog)
+	if err != nil {
+		return nil, noop, err
+	}
+	s.SetCatalog(catalog)
+
+	// languages
+	if storefront.Langs != "" {
+		s.Langs = lang.MakeLanguages(nil, strings.Split(storefront.Langs, ",")...)
+	}
+	if missing := s.Catalog.MissingTranslations(s.Langs); len(missing) > 0 {
+		slog.Warn("catalog has missing translations, see staff index page", "storefront", s.ID, "count", len(missing))
+	}
+
+	// templates
+	s.Site = html.DefaultSite
+	if storefront.SiteDir != "" {
+		site, err := html.ParseSite(os.DirFS(configPath(storefront.SiteDir)))
+		if err != nil {
+			return nil, noop, fmt.Errorf("parsing site dir: %w", err)
+		}
+		s.Site = site
+	}
+
+	// notification sender
+	if test {
+		s.Emailer = email.DummyMailer{}
+		slog.Warn("using dummy emailer", "storefront", s.ID)
+	} else {
+		s.Emailer = email.Sendmail{
+			From: storefront.EmailFrom,
+		}
+	}
+	s.Notifiers = maps.Clone(shared.Notifiers)
+	s.Notifiers["email"] = notify.Email{Emailer: s.Emailer}
+
+	// payment methods
+	var methods []payment.Method
+	if storefront.CashAddressHTML != "" {
+		methods = append(methods, payment.Cash{
+			AddressHTML: storefront.CashAddressHTML,
+		})
+		if s.RatesHistory != nil {
+			methods = append(methods, payment.CashForeign{
+				AddressHTML: storefront.CashAddressHTML,
+				History:     s.RatesHistory,
+				Purchases:   s,
+			})
+		}
+	}
+	if storefront.SEPAAccount != nil {
+		var sepa = payment.SEPA{Purchases: s}
+		if err := json.Unmarshal(storefront.SEPAAccount, &sepa.Account); err != nil {
+			return nil, noop, fmt.Errorf("sepa-account: %w", err)
+		}
+		methods = append(methods, sepa)
+	}
+
+	// btcpay, last because the stand-in must be closed
+	var closeBtcpay = noop
+	if test {
+		btcpayServer := btcpaytest.NewServer(localCustomerURL + "/payment/btcpay/webhook")
+		btcpayServer.WebhookHost = s.Hosts[0] // see hostMux
+		closeBtcpay = btcpayServer.Close
+		s.Btcpay = btcpayServer.Store()
+		slog.Warn("using btcpay dummy store", "storefront", s.ID, "host", s.Btcpay.Host)
+	} else {
+		store, err := btcpay.LoadConfig(configPath(cmp.Or(storefront.Btcpay, "btcpay.json")))
+		if err != nil {
+			return nil, noop, fmt.Errorf("loading btcpay store: %w", err)
+		}
+		s.Btcpay = store
+	}
+	s.BtcpayStatus = s.Btcpay.StatusDaemon()
+
+	s.PaymentMethods = append([]payment.Method{
+		&payment.BTCPay{
+			Purchases:    s,
+			RedirectPath: "/by-cookie",
+			Store:        s.Btcpay,
+			ErrCreateInvoice: func(err error) http.Handler {
+				return s.frontendErr(fmt.Errorf("creating invoice: %w", err), "Error creating BTCPay invoice")
+			},
+			ErrWebhook: func(err error) http.Handler {
+				slog.Error("webhook error", "storefront", s.ID, "method", "btcpay", "err", err)
+				s.metrics.webhookErrors.Inc("btcpay")
+				s.publishError(err)
+				return nil
+			},
+			GetStatus: s.BtcpayStatus,
+		},
+	}, methods...)
+
+	return s, closeBtcpay, nil
+}
+
+// configPath returns the path of a file in the configuration directory. Absolute paths are returned as they are.
+func configPath(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), name)
+}
+
+// hostMux dispatches customer requests to the storefronts by the host name of the request. Requests with an unknown host name, e.g. from clients which access the listener by its IP address, are served by the default storefront.
+type hostMux struct {
+	hosts    map[string]http.Handler
+	fallback http.Handler
+}
+
+func (mux hostMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if handler, ok := mux.hosts[strings.ToLower(stripPort(r.Host))]; ok {
+		handler.ServeHTTP(w, r)
+		return
+	}
+	mux.fallback.ServeHTTP(w, r)
+}
+
+// newHostMux returns a hostMux for the shops. The first shop is the default storefront.
+func newHostMux(shops []*Shop) (hostMux, error) {
+	var mux = hostMux{
+		hosts: make(map[string]http.Handler),
+	}
+	for i, s := range shops {
+		handler, err := s.CustomerHandler()
+		if err != nil {
+			return mux, fmt.Errorf("storefront %q: %w", s.ID, err)
+		}
+		for _, host := range s.Hosts {
+			mux.hosts[host] = handler
+		}
+		if i == 0 {
+			mux.fallback = handler
+		}
+	}
+	return mux, nil
+}
+
+// Serve runs the shops until an interrupt signal is received or any of the listeners fails. The shops share the customer listener, see hostMux, and each shop has its own staff listener. The first shop is the default storefront.
+func Serve(customerAddr string, shops []*Shop) {
+
+	var stop = make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	custHandler, err := newHostMux(shops)
+	if err != nil {
+		slog.Error("error making customer handler", "err", err)
+		os.Exit(1)
+	}
+	shutdownCust := httputil.ListenAndServe(customerAddr, custHandler, stop)
+	defer shutdownCust()
+
+	slog.Info("listening", "listener", "customer", "addr", customerAddr)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, s := range shops {
+		staffHandler, err := s.StaffHandler()
+		if err != nil {
+			slog.Error("error making staff handler", "storefront", s.ID, "err", err)
+			os.Exit(1)
+		}
+		shutdownStaff := httputil.ListenAndServe(s.StaffAddr, staffHandler, stop)
+		defer shutdownStaff()
+
+		slog.Info("listening", "listener", "staff", "storefront", s.ID, "addr", s.StaffAddr)
+
+		// cleanup bot
+
+		go func() {
+			for ; true; <-time.Tick(12 * time.Hour) {
+				wg.Add(1)
+				if err := s.Database.Cleanup(); err != nil {
+					slog.Error("error cleaning up database", "storefront", s.ID, "err", err)
+				}
+				wg.Done()
+			}
+		}()
+
+		// notification outbox
+		s.outboxWake = make(chan struct{}, 1)
+		go s.runOutbox(&wg)
+
+		// notify us
+		if err := s.Emailer.Send(s.EmailFrom, "digitalgoods service started", []byte("the digitalgoods service has been started")); err != nil {
+			slog.Error("error sending start email", "storefront", s.ID, "err", err)
+		}
+		if s.NtfyshTopic != "" {
+			if err := ntfysh.Publish(s.NtfyshTopic, "digitalgoods service started", "the digitalgoods service has been started"); err != nil {
+				slog.Error("error publishing start notification", "storefront", s.ID, "err", err)
+			}
+		}
+	}
+
+	// run until we receive an interrupt or any of the listeners fails
+
+	slog.Info("running", "storefronts", len(shops))
+	<-stop
+	slog.Info("shutting down")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dys2p/digitalgoods"
+)
+
+// newOtherShop returns a copy of the test shop with another storefront ID and catalog. The variant voucher-5 exists in both catalogs.
+func (ts *testShop) newOtherShop() *Shop {
+	var other = *ts.shop
+	other.ID = "other"
+	other.Hosts = []string{"other.example.com"}
+	other.Database = ts.shop.Database.Shop("other")
+	other.SetCatalog(digitalgoods.Catalog{
+		{
+			Name: map[string]string{"de": "Andere", "en": "Other"},
+			Articles: []digitalgoods.Article{
+				{
+					Brand: "Other",
+					Name:  "Other Voucher",
+					ID:    "other",
+					Variants: []digitalgoods.Variant{
+						{ID: "other-1", Name: "Other Voucher 1", Price: 100},
+						{ID: "voucher-5", Name: "Other Voucher 5", Price: 500},
+					},
+				},
+			},
+		},
+	})
+	return &other
+}
+
+func TestHostMux(t *testing.T) {
+	ts := newTestShop(t)
+	other := ts.newOtherShop()
+	if err := other.Database.AddToStock("other-1", "other-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	mux, err := newHostMux([]*Shop{ts.shop, other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, tc := range []struct {
+		host string
+		want string
+	}{
+		{"other.example.com", `"variant_id":"other-1"`},
+		{"OTHER.example.com:443", `"variant_id":"other-1"`},
+		{"unknown.example.com", `"variant_id":"voucher-10"`},
+	} {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/stock", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = tc.host
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), tc.want) {
+			t.Errorf("stock of host %s does not contain %s: %s", tc.host, tc.want, body)
+		}
+	}
+}
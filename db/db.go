@@ -1,13 +1,13 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
+	"log/slog"
 	"time"
 
 	"github.com/dys2p/digitalgoods"
@@ -16,10 +16,12 @@ import (
 
 type DB struct {
 	sqlDB *sql.DB
+	shop  string // see Shop
 
 	// purchases
 	insertPurchase               *sql.Stmt
 	cleanupPurchases             *sql.Stmt
+	cleanupDiscounts             *sql.Stmt
 	getIDByPattern               *sql.Stmt
 	getPurchaseByID              *sql.Stmt
 	getPurchaseByIDAndAccessKey  *sql.Stmt
@@ -41,11 +43,31 @@ type DB struct {
 	// sales tax log
 	getSales   *sql.Stmt
 	insertSale *sql.Stmt
+
+	// discounts
+	useDiscount     *sql.Stmt
+	releaseDiscount *sql.Stmt
+
+	// notification outbox
+	cleanupOutbox      *sql.Stmt
+	deleteOutbox       *sql.Stmt
+	enqueueOutbox      *sql.Stmt
+	getOutboxDue       *sql.Stmt
+	getOutboxFailed    *sql.Stmt
+	updateOutboxFailed *sql.Stmt
+	updateOutboxRetry  *sql.Stmt
+
+	// metrics
+	countDelivered     *sql.Stmt
+	countNotifications *sql.Stmt
+	countPurchases     *sql.Stmt
 }
 
-func OpenDB() (*DB, error) {
+// OpenDB opens or creates the SQLite database at the given path.
+func OpenDB(path string) (*DB, error) {
 
-	var sqlDB, err = sql.Open("sqlite3", filepath.Join(os.Getenv("STATE_DIRECTORY"), "digitalgoods.sqlite3?_busy_timeout=10000&_journal=WAL&_sync=NORMAL&cache=shared"))
+	// _txlock=immediate: transactions acquire the write lock when they begin, so read-modify-write transactions like SetSettled are serialized
+	var sqlDB, err = sql.Open("sqlite3", path+"?_busy_timeout=10000&_journal=WAL&_sync=NORMAL&_txlock=immediate")
 	if err != nil {
 		return nil, err
 	}
@@ -59,18 +81,24 @@ func OpenDB() (*DB, error) {
 			message     text not null,
 			notifyproto text not null,
 			notifyaddr  text not null,
+			notifykey   text not null default '', -- armored openpgp public key
 			ordered     text not null, -- json
 			delivered   text not null, -- json (codes removed from stock)
 			create_date text not null, -- yyyy-mm-dd
 			deletedate  text not null, -- yyyy-mm-dd
 			countrycode text not null,
+			lang        text not null default '', -- language prefix of the customer, for notifications
+			discount    text not null default '', -- discount code, released if the purchase is deleted unpaid
+			shop        text not null default '', -- see DB.Shop
 			unique(access_key),
 			unique(payment_key)
 		);
 		create table if not exists stock (
 			variant text not null,
-			payload text not null primary key,
-			addtime int  not null -- yyyy-mm-dd, sell oldest first
+			payload text not null,
+			addtime int  not null, -- yyyy-mm-dd, sell oldest first
+			shop    text not null default '',
+			primary key (shop, payload)
 		);
 		create table if not exists vat_log (
 			purchase       text not null, -- six-digit id
@@ -78,13 +106,47 @@ func OpenDB() (*DB, error) {
 			variant        text not null,
 			amount         int  not null,
 			itemprice      int  not null, -- euro cents
-			countrycode    text not null
+			countrycode    text not null,
+			shop           text not null default ''
+		);
+		create table if not exists discount_usage (
+			code text not null primary key,
+			uses int  not null -- number of purchases
+		);
+		create table if not exists outbox (
+			id          integer primary key,
+			purchase    text not null, -- contact data is read from the purchase when sending
+			event       text not null,
+			attempts    int  not null default 0,
+			next_try    int  not null, -- unix time
+			last_error  text not null default '',
+			dead        int  not null default 0, -- bool
+			create_time int  not null -- unix time
 		);
 	`)
 	if err != nil {
 		return nil, err
 	}
 
+	// add columns which are missing in databases created by older versions
+	if err := addColumn(sqlDB, "purchase", "notifykey", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "purchase", "lang", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	if err := addColumn(sqlDB, "purchase", "discount", "text not null default ''"); err != nil {
+		return nil, err
+	}
+	for _, table := range []string{"purchase", "stock", "vat_log"} {
+		if err := addColumn(sqlDB, table, "shop", "text not null default ''"); err != nil {
+			return nil, err
+		}
+	}
+	if err := migrateStockKey(sqlDB); err != nil {
+		return nil, err
+	}
+
 	var db = &DB{
 		sqlDB: sqlDB,
 	}
@@ -98,44 +160,46 @@ func OpenDB() (*DB, error) {
 	}
 
 	// purchase
-	db.insertPurchase = mustPrepare("insert into purchase (id, access_key, payment_key, status, message, notifyproto, notifyaddr, ordered, delivered, create_date, deletedate, countrycode) values (?, ?, ?, ?, ?, ?, ?, ?, '[]', ?, ?, ?)")
-	db.cleanupPurchases = mustPrepare("delete from purchase where status = ? and deletedate != '' and deletedate < ?")
-	db.getIDByPattern = mustPrepare("select id from purchase where id like ? limit 10")
-	db.getPurchaseByID = mustPrepare("             select id, access_key, payment_key, status, message, notifyproto, notifyaddr, ordered, delivered, create_date, deletedate, countrycode from purchase where id = ? limit 1")
-	db.getPurchaseByIDAndAccessKey = mustPrepare(" select id, access_key, payment_key, status, message, notifyproto, notifyaddr, ordered, delivered, create_date, deletedate, countrycode from purchase where id = ? and access_key = ? limit 1")
-	db.getPurchaseByIDAndPaymentKey = mustPrepare("select id, access_key, payment_key, status, message, notifyproto, notifyaddr, ordered, delivered, create_date, deletedate, countrycode from purchase where id = ? and payment_key = ? limit 1")
-	db.getPurchasesByStatus = mustPrepare("select id from purchase where status = ?")
+	db.insertPurchase = mustPrepare("insert into purchase (id, access_key, payment_key, status, message, notifyproto, notifyaddr, ordered, delivered, create_date, deletedate, countrycode, lang, discount, shop) values (?, ?, ?, ?, ?, ?, ?, ?, '[]', ?, ?, ?, ?, ?, ?)")
+	db.cleanupPurchases = mustPrepare("delete from purchase where status = ? and deletedate != '' and deletedate < ? and shop = ?")
+	db.cleanupDiscounts = mustPrepare("select discount, count(*) from purchase where status = ? and deletedate != '' and deletedate < ? and shop = ? and discount != '' group by discount")
+	db.getIDByPattern = mustPrepare("select id from purchase where id like ? and shop = ? limit 10")
+	db.getPurchaseByID = mustPrepare("             select id, access_key, payment_key, status, message, notifyproto, notifyaddr, notifykey, ordered, delivered, create_date, deletedate, countrycode, lang from purchase where id = ? and shop = ? limit 1")
+	db.getPurchaseByIDAndAccessKey = mustPrepare(" select id, access_key, payment_key, status, message, notifyproto, notifyaddr, notifykey, ordered, delivered, create_date, deletedate, countrycode, lang from purchase where id = ? and access_key = ? and shop = ? limit 1")
+	db.getPurchaseByIDAndPaymentKey = mustPrepare("select id, access_key, payment_key, status, message, notifyproto, notifyaddr, notifykey, ordered, delivered, create_date, deletedate, countrycode, lang from purchase where id = ? and payment_key = ? and shop = ? limit 1")
+	db.getPurchasesByStatus = mustPrepare("select id from purchase where status = ? and shop = ?")
 	db.updatePurchase = mustPrepare("update purchase set status = ?, delivered = ?, deletedate = ? where id = ?")
 	db.updatePurchaseCountry = mustPrepare("update purchase set countrycode = ?                 where id = ?")
 	db.updatePurchaseMessage = mustPrepare("update purchase set message = ?, deletedate = ?     where id = ?")
-	db.updatePurchaseNotify = mustPrepare(" update purchase set notifyproto = ?, notifyaddr = ? where id = ?")
+	db.updatePurchaseNotify = mustPrepare(" update purchase set notifyproto = ?, notifyaddr = ?, notifykey = ?, lang = ? where id = ?")
 	db.updatePurchaseStatus = mustPrepare(" update purchase set status = ?, deletedate = ?      where id = ?")
 
 	// stock
 	db.addToStock = mustPrepare(`
-		insert into stock (variant, payload, addtime)
-		values (?, ?, ?)
+		insert into stock (variant, payload, addtime, shop)
+		values (?, ?, ?, ?)
 	`)
 	db.deleteFromStock = mustPrepare(`
 		delete
 		from stock
-		where payload = ?
-	`) // payload is primary key
+		where payload = ? and shop = ?
+	`) // primary key
 	db.getFromStock = mustPrepare(`
 		select payload
 		from stock
-		where variant = ?
+		where variant = ? and shop = ?
 		order by addtime asc
 		limit ?
 	`)
 	db.getStock = mustPrepare(`
 		select count(1)
 		from stock
-		where variant = ?
+		where variant = ? and shop = ?
 	`)
 	db.getStockAll = mustPrepare(`
 		select variant, count(1)
 		from stock
+		where shop = ?
 		group by variant
 	`)
 
@@ -149,34 +213,144 @@ func OpenDB() (*DB, error) {
 			amount,
 			amount * itemprice
 		from vat_log
-		where deliverydate >= ?`)
-	db.insertSale = mustPrepare("insert into vat_log (purchase, deliverydate, variant, amount, itemprice, countrycode) values (?, ?, ?, ?, ?, ?)")
+		where deliverydate >= ? and shop = ?`)
+	db.insertSale = mustPrepare("insert into vat_log (purchase, deliverydate, variant, amount, itemprice, countrycode, shop) values (?, ?, ?, ?, ?, ?, ?)")
+
+	// discounts
+	db.useDiscount = mustPrepare(`
+		insert into discount_usage (code, uses)
+		values (?, 1)
+		on conflict (code) do update
+		set uses = uses + 1
+		where ? = 0 or uses < ?
+	`)
+	db.releaseDiscount = mustPrepare("update discount_usage set uses = max(uses - ?, 0) where code = ?")
+
+	// notification outbox
+	db.cleanupOutbox = mustPrepare("delete from outbox where purchase not in (select id from purchase)")
+	db.deleteOutbox = mustPrepare("delete from outbox where id = ? and purchase in (select id from purchase where shop = ?)")
+	db.enqueueOutbox = mustPrepare("insert into outbox (purchase, event, next_try, create_time) select id, ?, ?, ? from purchase where id = ? and notifyproto != '' and notifyaddr != ''")
+	db.getOutboxDue = mustPrepare("select id, purchase, event, attempts, next_try, last_error, dead, create_time from outbox where dead = 0 and next_try <= ? and purchase in (select id from purchase where shop = ?) order by id limit ?")
+	db.getOutboxFailed = mustPrepare("select id, purchase, event, attempts, next_try, last_error, dead, create_time from outbox where attempts > 0 and purchase in (select id from purchase where shop = ?) order by id desc")
+	db.updateOutboxFailed = mustPrepare("update outbox set attempts = attempts + 1, next_try = ?, last_error = ?, dead = ? where id = ? and purchase in (select id from purchase where shop = ?)")
+	db.updateOutboxRetry = mustPrepare("update outbox set next_try = ?, dead = 0 where id = ? and purchase in (select id from purchase where shop = ?)")
+
+	// metrics
+	db.countDelivered = mustPrepare("select variant, sum(amount) from vat_log where shop = ? group by variant")
+	db.countNotifications = mustPrepare("select count(*), coalesce(sum(attempts > 0 and dead = 0), 0), coalesce(sum(dead), 0) from outbox where purchase in (select id from purchase where shop = ?)")
+	db.countPurchases = mustPrepare("select status, count(*), min(create_date) from purchase where shop = ? group by status")
 
 	return db, nil
 }
 
-func (db *DB) InsertPurchase(purchase *digitalgoods.Purchase) error {
+// addColumn adds a column to a table unless it exists.
+func addColumn(sqlDB *sql.DB, table, column, definition string) error {
+	var n int
+	if err := sqlDB.QueryRow("select count(1) from pragma_table_info(?) where name = ?", table, column).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	_, err := sqlDB.Exec(fmt.Sprintf("alter table %s add column %s %s", table, column, definition))
+	return err
+}
+
+// migrateStockKey rebuilds the stock table of databases created by older versions, whose primary key is the payload alone, so it becomes (shop, payload). SQLite can't alter a primary key.
+func migrateStockKey(sqlDB *sql.DB) error {
+	var pk int
+	if err := sqlDB.QueryRow("select pk from pragma_table_info('stock') where name = 'shop'").Scan(&pk); err != nil {
+		return err
+	}
+	if pk > 0 {
+		return nil
+	}
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`
+		create table stock_new (
+			variant text not null,
+			payload text not null,
+			addtime int  not null, -- yyyy-mm-dd, sell oldest first
+			shop    text not null default '',
+			primary key (shop, payload)
+		);
+		insert into stock_new (variant, payload, addtime, shop) select variant, payload, addtime, shop from stock;
+		drop table stock;
+		alter table stock_new rename to stock;
+	`)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Shop returns a DB which shares the connection and the statements with db, but reads and writes only the purchases, stock and sales of the given shop. Databases of older versions belong to the shop with the empty ID, which is also used by OpenDB.
+func (db *DB) Shop(id string) *DB {
+	var scoped = *db
+	scoped.shop = id
+	return &scoped
+}
+
+// Close closes the database connection, which is shared by all shops.
+func (db *DB) Close() error {
+	return db.sqlDB.Close()
+}
+
+// InsertPurchase inserts the purchase with a new ID. If the order has a discount code, its use is counted in the same transaction. If maxUses is greater than zero and the code has already been used maxUses times, it returns digitalgoods.ErrDiscountUsedUp and the purchase is not inserted.
+func (db *DB) InsertPurchase(purchase *digitalgoods.Purchase, maxUses int) error {
 	orderJson, err := json.Marshal(purchase.Ordered)
 	if err != nil {
 		return err
 	}
+	discount := purchase.Ordered.DiscountCode()
+
+	tx, err := db.sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if discount != "" {
+		result, err := tx.Stmt(db.useDiscount).Exec(db.discountKey(discount), maxUses, maxUses)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return digitalgoods.ErrDiscountUsedUp
+		}
+	}
+
 	for i := 0; i < 5; i++ { // try five times if pay id already exists, see id.New
 		purchase.ID = id.New(6, id.AlphanumCaseInsensitiveDigits)
-		if _, err = db.insertPurchase.Exec(purchase.ID, purchase.AccessKey, purchase.PaymentKey, purchase.Status, purchase.Message, purchase.NotifyProto, purchase.NotifyAddr, orderJson, purchase.CreateDate, purchase.DeleteDate, purchase.CountryCode); err == nil {
-			return nil
+		if _, err = tx.Stmt(db.insertPurchase).Exec(purchase.ID, purchase.AccessKey, purchase.PaymentKey, purchase.Status, purchase.Message, purchase.NotifyProto, purchase.NotifyAddr, orderJson, purchase.CreateDate, purchase.DeleteDate, purchase.CountryCode, purchase.Lang, discount, db.shop); err == nil {
+			return tx.Commit()
 		}
 	}
-	log.Printf("database ran out of IDs, or other error: %v", err)
+	slog.Error("database ran out of IDs, or other error", "err", err)
 	return errors.New("database ran out of IDs")
 }
 
+// discountKey returns the key of the discount code in the discount_usage table. Codes of other shops than the default shop are prefixed with the shop ID, so the primary key of older databases can be kept.
+func (db *DB) discountKey(code string) string {
+	if db.shop == "" {
+		return code
+	}
+	return db.shop + "/" + code
+}
+
 func (db *DB) AddToStock(variantID, payload string) error {
-	_, err := db.addToStock.Exec(variantID, payload, time.Now().Format(digitalgoods.DateFmt))
+	_, err := db.addToStock.Exec(variantID, payload, time.Now().Format(digitalgoods.DateFmt), db.shop)
 	return err
 }
 
 func (db *DB) GetStock() (digitalgoods.Stock, error) {
-	rows, err := db.getStockAll.Query()
+	rows, err := db.getStockAll.Query(db.shop)
 	if err != nil {
 		return nil, err
 	}
@@ -195,28 +369,79 @@ func (db *DB) GetStock() (digitalgoods.Stock, error) {
 }
 
 func (db *DB) Cleanup() error {
+	today := time.Now().Format(digitalgoods.DateFmt)
+
 	// new
-	result, err := db.cleanupPurchases.Exec(digitalgoods.StatusNew, time.Now().Format(digitalgoods.DateFmt))
+	if err := db.cleanupNew(today); err != nil {
+		return err
+	}
+
+	// finalized
+	result, err := db.cleanupPurchases.Exec(digitalgoods.StatusFinalized, today, db.shop)
 	if err != nil {
 		return err
 	}
 	if ra, _ := result.RowsAffected(); ra > 0 {
-		log.Printf("deleted %d new purchases", ra)
+		slog.Info("deleted finalized purchases", "count", ra)
 	}
 
-	// finalized
-	result, err = db.cleanupPurchases.Exec(digitalgoods.StatusFinalized, time.Now().Format(digitalgoods.DateFmt))
+	// notifications of deleted purchases
+	result, err = db.cleanupOutbox.Exec()
 	if err != nil {
 		return err
 	}
 	if ra, _ := result.RowsAffected(); ra > 0 {
-		log.Printf("deleted %d finalized purchases", ra)
+		slog.Info("deleted notifications", "count", ra)
+	}
+	return nil
+}
+
+// cleanupNew deletes the expired unpaid purchases and releases the uses of their discount codes.
+func (db *DB) cleanupNew(today string) error {
+	tx, err := db.sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Stmt(db.cleanupDiscounts).Query(digitalgoods.StatusNew, today, db.shop)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var uses = make(map[string]int) // key: discount code
+	for rows.Next() {
+		var code string
+		var n int
+		if err := rows.Scan(&code, &n); err != nil {
+			return err
+		}
+		uses[code] = n
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for code, n := range uses {
+		if _, err := tx.Stmt(db.releaseDiscount).Exec(n, db.discountKey(code)); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.Stmt(db.cleanupPurchases).Exec(digitalgoods.StatusNew, today, db.shop)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if ra, _ := result.RowsAffected(); ra > 0 {
+		slog.Info("deleted new purchases", "count", ra)
 	}
 	return nil
 }
 
 func (db *DB) GetIDsByPattern(pattern string) ([]string, error) {
-	rows, err := db.getIDByPattern.Query(pattern)
+	rows, err := db.getIDByPattern.Query(pattern, db.shop)
 	if err != nil {
 		return nil, err
 	}
@@ -239,17 +464,12 @@ func (db *DB) GetIDsByPattern(pattern string) ([]string, error) {
 
 // FulfilUnderdelivered calls SetSettled for all underdelivered purchases. It can be called at any time.
 func (db *DB) FulfilUnderdelivered(catalog digitalgoods.Catalog) error {
-	// no transaction required because SetSettled is idempotent
-	rows, err := db.getPurchasesByStatus.Query(digitalgoods.StatusUnderdelivered)
+	// no transaction required because SetSettled is idempotent and reads the purchase again
+	ids, err := db.GetPurchases(digitalgoods.StatusUnderdelivered)
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return err
-		}
+	for _, id := range ids {
 		purchase, err := db.GetPurchaseByID(id)
 		if err != nil {
 			return err
@@ -262,15 +482,15 @@ func (db *DB) FulfilUnderdelivered(catalog digitalgoods.Catalog) error {
 }
 
 func (db *DB) GetPurchaseByID(id string) (*digitalgoods.Purchase, error) {
-	return db.getPurchaseWithStmt(db.getPurchaseByID, id)
+	return db.getPurchaseWithStmt(db.getPurchaseByID, id, db.shop)
 }
 
 func (db *DB) GetPurchaseByIDAndAccessKey(id, accessKey string) (*digitalgoods.Purchase, error) {
-	return db.getPurchaseWithStmt(db.getPurchaseByIDAndAccessKey, id, accessKey)
+	return db.getPurchaseWithStmt(db.getPurchaseByIDAndAccessKey, id, accessKey, db.shop)
 }
 
 func (db *DB) GetPurchaseByIDAndPaymentKey(id, paymentKey string) (*digitalgoods.Purchase, error) {
-	return db.getPurchaseWithStmt(db.getPurchaseByIDAndPaymentKey, id, paymentKey)
+	return db.getPurchaseWithStmt(db.getPurchaseByIDAndPaymentKey, id, paymentKey, db.shop)
 }
 
 // can be used within or without a transaction
@@ -278,7 +498,7 @@ func (db *DB) getPurchaseWithStmt(stmt *sql.Stmt, args ...any) (*digitalgoods.Pu
 	var purchase = &digitalgoods.Purchase{}
 	var ordered string
 	var delivered string
-	if err := stmt.QueryRow(args...).Scan(&purchase.ID, &purchase.AccessKey, &purchase.PaymentKey, &purchase.Status, &purchase.Message, &purchase.NotifyProto, &purchase.NotifyAddr, &ordered, &delivered, &purchase.CreateDate, &purchase.DeleteDate, &purchase.CountryCode); err != nil {
+	if err := stmt.QueryRow(args...).Scan(&purchase.ID, &purchase.AccessKey, &purchase.PaymentKey, &purchase.Status, &purchase.Message, &purchase.NotifyProto, &purchase.NotifyAddr, &purchase.NotifyKey, &ordered, &delivered, &purchase.CreateDate, &purchase.DeleteDate, &purchase.CountryCode, &purchase.Lang); err != nil {
 		return nil, err
 	}
 	if err := json.Unmarshal([]byte(ordered), &purchase.Ordered); err != nil {
@@ -292,7 +512,7 @@ func (db *DB) getPurchaseWithStmt(stmt *sql.Stmt, args ...any) (*digitalgoods.Pu
 
 // GetPurchases returns the IDs of all purchases with the given status.
 func (db *DB) GetPurchases(status digitalgoods.Status) ([]string, error) {
-	rows, err := db.getPurchasesByStatus.Query(status)
+	rows, err := db.getPurchasesByStatus.Query(status, db.shop)
 	if err != nil {
 		return nil, err
 	}
@@ -308,9 +528,21 @@ func (db *DB) GetPurchases(status digitalgoods.Status) ([]string, error) {
 	return ids, nil
 }
 
+// SetProcessing sets the status of the purchase and enqueues a notification.
 func (db *DB) SetProcessing(purchase *digitalgoods.Purchase) error {
-	_, err := db.updatePurchaseStatus.Exec(digitalgoods.StatusPaymentProcessing, time.Now().AddDate(0, 0, 31).Format(digitalgoods.DateFmt), purchase.ID)
-	return err
+	tx, err := db.sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Stmt(db.updatePurchaseStatus).Exec(digitalgoods.StatusPaymentProcessing, time.Now().AddDate(0, 0, 31).Format(digitalgoods.DateFmt), purchase.ID); err != nil {
+		return err
+	}
+	if err := db.enqueue(tx, purchase.ID, digitalgoods.EventProcessing); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (db *DB) SetCountry(purchase *digitalgoods.Purchase, countryCode string) error {
@@ -318,35 +550,62 @@ func (db *DB) SetCountry(purchase *digitalgoods.Purchase, countryCode string) er
 	return err
 }
 
+// SetMessage sets the message of the purchase. If the message is new, a notification is enqueued.
 func (db *DB) SetMessage(purchase *digitalgoods.Purchase, message, deleteDate string) error {
-	_, err := db.updatePurchaseMessage.Exec(message, deleteDate, purchase.ID)
-	return err
+	tx, err := db.sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Stmt(db.updatePurchaseMessage).Exec(message, deleteDate, purchase.ID); err != nil {
+		return err
+	}
+	if message != "" && message != purchase.Message {
+		if err := db.enqueue(tx, purchase.ID, digitalgoods.EventMessage); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (db *DB) SetNotify(purchase *digitalgoods.Purchase) error {
-	_, err := db.updatePurchaseNotify.Exec(purchase.NotifyProto, purchase.NotifyAddr, purchase.ID)
+	_, err := db.updatePurchaseNotify.Exec(purchase.NotifyProto, purchase.NotifyAddr, purchase.NotifyKey, purchase.Lang, purchase.ID)
 	return err
 }
 
-// idempotent, must be called only if the invoice has been paid
+// SetSettled delivers the unfulfilled items of the purchase from stock. It is idempotent and must be called only if the invoice has been paid.
+//
+// The purchase is read again within a write transaction, so concurrent calls for the same purchase (e.g. a webhook redelivery and FulfilUnderdelivered after an upload) are serialized and can't deliver twice. The given purchase is updated.
 func (db *DB) SetSettled(purchase *digitalgoods.Purchase, catalog digitalgoods.Catalog) error {
 
-	tx, err := db.sqlDB.Begin()
+	tx, err := db.sqlDB.Begin() // immediate, see OpenDB
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback() // no effect if tx has been committed
 
+	current, err := db.getPurchaseWithStmt(tx.Stmt(db.getPurchaseByID), purchase.ID, db.shop)
+	if err != nil {
+		return fmt.Errorf("reading %s within transaction: %w", purchase.ID, err)
+	}
+	*purchase = *current
+	previousStatus := purchase.Status
+
 	unfulfilled, err := purchase.GetUnfulfilled()
 	if err != nil {
 		return err
 	}
-	if len(unfulfilled) == 0 {
+	if unfulfilled.Empty() && purchase.Status == digitalgoods.StatusFinalized {
 		return nil
 	}
 
 	for _, orderRow := range unfulfilled {
 
+		if orderRow.Quantity <= 0 {
+			continue // "limit -1" would return all rows
+		}
+
 		variant, ok := catalog.Variant(orderRow.VariantID)
 		if !ok {
 			return fmt.Errorf("setting %s settled: variant %s not found", purchase.ID, orderRow.VariantID)
@@ -354,35 +613,31 @@ func (db *DB) SetSettled(purchase *digitalgoods.Purchase, catalog digitalgoods.C
 
 		// get from stock
 
-		rows, err := tx.Stmt(db.getFromStock).Query(variant.StockID(), orderRow.Quantity)
+		payloads, err := getFromStock(tx, db.getFromStock, variant.StockID(), db.shop, orderRow.Quantity)
 		if err != nil {
 			return err
 		}
-		defer rows.Close()
-
-		var gotQuantity = 0
 
-		for rows.Next() {
-			var payload string
-			if err := rows.Scan(&payload); err != nil {
+		for _, payload := range payloads {
+			result, err := tx.Stmt(db.deleteFromStock).Exec(payload, db.shop)
+			if err != nil {
 				return err
 			}
-			if _, err := tx.Stmt(db.deleteFromStock).Exec(payload); err != nil {
-				return err
+			if ra, _ := result.RowsAffected(); ra != 1 {
+				return fmt.Errorf("[%s] removing %s from stock: got %d affected rows", purchase.ID, digitalgoods.Mask(payload), ra)
 			}
-			log.Printf("[%s] delivering %s: %s", purchase.ID, variant.StockID(), digitalgoods.Mask(payload))
+			slog.Info("delivering", "purchase", purchase.ID, "stock", variant.StockID(), "payload", payload)
 			purchase.Delivered = append(purchase.Delivered, digitalgoods.DeliveredItem{
 				VariantID:    orderRow.VariantID, // not StockID because customer ordered a specific variant
 				Payload:      payload,
 				DeliveryDate: time.Now().Format(digitalgoods.DateFmt),
 			})
-			gotQuantity++
 		}
 
 		// sales tax log
 
-		if gotQuantity > 0 {
-			if _, err := tx.Stmt(db.insertSale).Exec(purchase.ID, time.Now().Format(digitalgoods.DateFmt), orderRow.VariantID, gotQuantity, orderRow.ItemPrice, purchase.CountryCode); err != nil {
+		if len(payloads) > 0 {
+			if _, err := tx.Stmt(db.insertSale).Exec(purchase.ID, time.Now().Format(digitalgoods.DateFmt), orderRow.VariantID, len(payloads), orderRow.ItemPrice, purchase.CountryCode, db.shop); err != nil {
 				return err
 			}
 		}
@@ -409,11 +664,47 @@ func (db *DB) SetSettled(purchase *digitalgoods.Purchase, catalog digitalgoods.C
 		return err
 	}
 
+	// notify customer, but not about partial deliveries to underdelivered purchases
+	var event digitalgoods.Event
+	switch {
+	case previousStatus == digitalgoods.StatusUnderdelivered && purchase.Status == digitalgoods.StatusFinalized:
+		event = digitalgoods.EventRemainingDelivered
+	case previousStatus == digitalgoods.StatusUnderdelivered:
+	case purchase.Status == digitalgoods.StatusFinalized:
+		event = digitalgoods.EventDelivered
+	default:
+		event = digitalgoods.EventUnderdelivered
+	}
+	if event != "" {
+		if err := db.enqueue(tx, purchase.ID, event); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit()
 }
 
+// getFromStock reads the payloads completely, so the rows are closed before they are deleted.
+func getFromStock(tx *sql.Tx, stmt *sql.Stmt, stockID, shop string, n int) ([]string, error) {
+	rows, err := tx.Stmt(stmt).Query(stockID, shop, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payloads []string
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, rows.Err()
+}
+
 func (db *DB) GetSales(minDate string) ([]digitalgoods.Sale, error) {
-	rows, err := db.getSales.Query(minDate)
+	rows, err := db.getSales.Query(minDate, db.shop)
 	if err != nil {
 		return nil, err
 	}
@@ -429,3 +720,129 @@ func (db *DB) GetSales(minDate string) ([]digitalgoods.Sale, error) {
 	}
 	return sales, nil
 }
+
+// enqueue adds a notification to the outbox, within the transaction which changes the purchase. Nothing is enqueued if the purchase has no contact data.
+func (db *DB) enqueue(tx *sql.Tx, purchaseID string, event digitalgoods.Event) error {
+	now := time.Now().Unix()
+	_, err := tx.Stmt(db.enqueueOutbox).Exec(event, now, now, purchaseID)
+	return err
+}
+
+// GetDueNotifications returns up to limit notifications which are not dead and due at the given time, oldest first.
+func (db *DB) GetDueNotifications(now int64, limit int) ([]digitalgoods.OutboxEntry, error) {
+	return db.getOutbox(db.getOutboxDue, now, db.shop, limit)
+}
+
+// GetFailedNotifications returns the notifications which have failed at least once, including dead ones, newest first.
+func (db *DB) GetFailedNotifications() ([]digitalgoods.OutboxEntry, error) {
+	return db.getOutbox(db.getOutboxFailed, db.shop)
+}
+
+func (db *DB) getOutbox(stmt *sql.Stmt, args ...any) ([]digitalgoods.OutboxEntry, error) {
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []digitalgoods.OutboxEntry
+	for rows.Next() {
+		var entry digitalgoods.OutboxEntry
+		if err := rows.Scan(&entry.ID, &entry.PurchaseID, &entry.Event, &entry.Attempts, &entry.NextTry, &entry.LastError, &entry.Dead, &entry.CreateTime); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// DeleteNotification removes a notification from the outbox, usually after it has been sent. Notifications of deleted purchases are removed by Cleanup.
+func (db *DB) DeleteNotification(id int64) error {
+	_, err := db.deleteOutbox.Exec(id, db.shop)
+	return err
+}
+
+// FailNotification records a failed attempt. If dead is true, no more attempts are made.
+func (db *DB) FailNotification(id int64, lastError string, nextTry int64, dead bool) error {
+	_, err := db.updateOutboxFailed.Exec(nextTry, lastError, dead, id, db.shop)
+	return err
+}
+
+// RetryNotification makes a notification due immediately, even if it is dead.
+func (db *DB) RetryNotification(id int64) error {
+	_, err := db.updateOutboxRetry.Exec(time.Now().Unix(), id, db.shop)
+	return err
+}
+
+// CountPurchases returns the number of purchases and the oldest creation date (yyyy-mm-dd) by status.
+func (db *DB) CountPurchases() (map[digitalgoods.Status]int, map[digitalgoods.Status]string, error) {
+	rows, err := db.countPurchases.Query(db.shop)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var counts = make(map[digitalgoods.Status]int)
+	var oldest = make(map[digitalgoods.Status]string)
+	for rows.Next() {
+		var status digitalgoods.Status
+		var count int
+		var createDate string
+		if err := rows.Scan(&status, &count, &createDate); err != nil {
+			return nil, nil, err
+		}
+		counts[status] = count
+		oldest[status] = createDate
+	}
+	return counts, oldest, rows.Err()
+}
+
+// CountDelivered returns the number of delivered items by variant ID, according to the sales tax log.
+func (db *DB) CountDelivered() (map[string]int, error) {
+	rows, err := db.countDelivered.Query(db.shop)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts = make(map[string]int)
+	for rows.Next() {
+		var variant string
+		var count int
+		if err := rows.Scan(&variant, &count); err != nil {
+			return nil, err
+		}
+		counts[variant] = count
+	}
+	return counts, rows.Err()
+}
+
+// CountNotifications returns the number of notifications in the outbox, and how many of them have failed or are dead.
+func (db *DB) CountNotifications() (total, failing, dead int, err error) {
+	err = db.countNotifications.QueryRow(db.shop).Scan(&total, &failing, &dead)
+	return
+}
+
+// CheckWritable checks whether the given SQLite database can be written. It acquires the write lock with "begin immediate" and releases it again, so nothing is written. Note that SQLite skips the lock on read-only connections.
+func CheckWritable(sqlDB *sql.DB) error {
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx) // begin and rollback must run on the same connection
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "begin immediate"); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, "rollback"); err != nil {
+		// the connection might still hold the write lock, so it must not go back to the pool
+		conn.Raw(func(any) error { return driver.ErrBadConn })
+		return err
+	}
+	return nil
+}
+
+// CheckWritable checks whether the purchase database is writable.
+func (db *DB) CheckWritable() error {
+	return CheckWritable(db.sqlDB)
+}
@@ -0,0 +1,417 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dys2p/digitalgoods"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testCatalog returns the fixture catalog. Both variants use a common stock.
+func testCatalog() digitalgoods.Catalog {
+	return digitalgoods.Catalog{
+		{
+			Articles: []digitalgoods.Article{
+				{
+					ID: "shared",
+					Variants: []digitalgoods.Variant{
+						{ID: "shared-a", Price: 300, OptionalStockID: "shared"},
+						{ID: "shared-b", Price: 300, OptionalStockID: "shared"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := OpenDB(filepath.Join(t.TempDir(), "digitalgoods.sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// insertPurchase inserts a new purchase of the shared variants. The access and payment keys are derived from the purchase number n, which must be unique within the test.
+func insertPurchase(t *testing.T, db *DB, n, a, b int) *digitalgoods.Purchase {
+	t.Helper()
+	purchase := &digitalgoods.Purchase{
+		AccessKey:  fmt.Sprintf("access-%d", n),
+		PaymentKey: fmt.Sprintf("payment-%d", n),
+		Status:     digitalgoods.StatusNew,
+		Ordered: digitalgoods.Order{
+			{VariantID: "shared-a", Quantity: a, ItemPrice: 300},
+			{VariantID: "shared-b", Quantity: b, ItemPrice: 300},
+		},
+		CreateDate:  time.Now().Format(digitalgoods.DateFmt),
+		DeleteDate:  time.Now().AddDate(0, 0, 31).Format(digitalgoods.DateFmt),
+		CountryCode: "DE",
+	}
+	if err := db.InsertPurchase(purchase, 0); err != nil {
+		t.Fatal(err)
+	}
+	return purchase
+}
+
+func addToStock(t *testing.T, db *DB, payloads ...string) {
+	t.Helper()
+	for _, payload := range payloads {
+		if err := db.AddToStock("shared", payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// checkDelivered checks that the purchases are finalized, have not been over-delivered and that no payload has been delivered twice, neither within a purchase nor across purchases. It returns the number of delivered payloads.
+func checkDelivered(t *testing.T, db *DB, purchases []*digitalgoods.Purchase) int {
+	t.Helper()
+	var seen = make(map[string]string)
+	for _, purchase := range purchases {
+		purchase, err := db.GetPurchaseByID(purchase.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if purchase.Status != digitalgoods.StatusFinalized {
+			t.Fatalf("purchase %s has status %s, want finalized", purchase.ID, purchase.Status)
+		}
+		unfulfilled, err := purchase.GetUnfulfilled()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range unfulfilled {
+			if row.Quantity < 0 {
+				t.Fatalf("purchase %s: variant %s over-delivered by %d", purchase.ID, row.VariantID, -row.Quantity)
+			}
+		}
+		for _, item := range purchase.Delivered {
+			if other, ok := seen[item.Payload]; ok {
+				t.Fatalf("payload %s delivered twice, to %s and %s", item.Payload, other, purchase.ID)
+			}
+			seen[item.Payload] = purchase.ID
+		}
+	}
+	return len(seen)
+}
+
+// TestConcurrentSettlement settles two purchases which compete for a shared stock, with webhook redeliveries and fulfilment after uploads at the same time.
+func TestConcurrentSettlement(t *testing.T) {
+	db := openTestDB(t)
+	catalog := testCatalog()
+
+	var purchases []*digitalgoods.Purchase
+	for n := range 2 {
+		purchases = append(purchases, insertPurchase(t, db, n, 5, 5))
+	}
+	for i := range 13 {
+		addToStock(t, db, fmt.Sprintf("shared-%d", i))
+	}
+
+	var wg sync.WaitGroup
+	var errs = make(chan error, 100)
+	for range 8 {
+		for _, purchase := range purchases {
+			wg.Go(func() {
+				purchase, err := db.GetPurchaseByIDAndPaymentKey(purchase.ID, purchase.PaymentKey)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if err := db.SetSettled(purchase, catalog); err != nil {
+					errs <- err
+				}
+			})
+		}
+		wg.Go(func() {
+			if err := db.FulfilUnderdelivered(catalog); err != nil {
+				errs <- err
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	// add the rest
+	for i := 13; i < 20; i++ {
+		addToStock(t, db, fmt.Sprintf("shared-%d", i))
+	}
+	if err := db.FulfilUnderdelivered(catalog); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := checkDelivered(t, db, purchases); n != 20 {
+		t.Fatalf("got %d delivered codes, want 20", n)
+	}
+	sales, err := db.GetSales("0000-00-00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sold int
+	for _, sale := range sales {
+		sold += sale.Quantity
+	}
+	if sold != 20 {
+		t.Fatalf("got %d items in sales log, want 20", sold)
+	}
+}
+
+// TestSettlementStress settles many purchases with outdated copies while codes are added in small batches. No code must be delivered twice and no purchase must be over-delivered.
+func TestSettlementStress(t *testing.T) {
+	const purchaseCount = 10
+	const rounds = 10
+
+	db := openTestDB(t)
+	catalog := testCatalog()
+
+	var purchases []*digitalgoods.Purchase
+	for n := range purchaseCount {
+		purchases = append(purchases, insertPurchase(t, db, n, 3, 2))
+	}
+
+	var wg sync.WaitGroup
+	var errs = make(chan error, purchaseCount*rounds*4)
+	for round := range rounds {
+		for i := range purchaseCount / 2 {
+			addToStock(t, db, fmt.Sprintf("stress-%d-%d", round, i))
+		}
+		for _, purchase := range purchases {
+			for range 3 {
+				outdated := *purchase // copy, as if read before another settlement
+				wg.Go(func() {
+					if err := db.SetSettled(&outdated, catalog); err != nil {
+						errs <- err
+					}
+				})
+			}
+		}
+		wg.Go(func() {
+			if err := db.FulfilUnderdelivered(catalog); err != nil {
+				errs <- err
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if n := checkDelivered(t, db, purchases); n != purchaseCount*5 {
+		t.Fatalf("got %d delivered codes, want %d", n, purchaseCount*5)
+	}
+	stock, err := db.GetStock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stock["shared"] != 0 {
+		t.Fatalf("got %d remaining codes, want 0", stock["shared"])
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	db := openTestDB(t)
+	catalog := testCatalog()
+
+	expired := insertPurchase(t, db, 0, 1, 0)
+	yesterday := time.Now().AddDate(0, 0, -1).Format(digitalgoods.DateFmt)
+	if err := db.SetMessage(expired, "", yesterday); err != nil {
+		t.Fatal(err)
+	}
+
+	underdelivered := insertPurchase(t, db, 1, 1, 0)
+	if err := db.SetSettled(underdelivered, catalog); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.GetPurchaseByID(expired.ID); err == nil {
+		t.Fatalf("expired purchase %s has not been deleted", expired.ID)
+	}
+	if purchase, err := db.GetPurchaseByID(underdelivered.ID); err != nil || purchase.Status != digitalgoods.StatusUnderdelivered {
+		t.Fatalf("underdelivered purchase must not be deleted: %v", err)
+	}
+}
+
+// TestCheckWritable checks that the probe creates no tables and fails if another connection holds the write lock.
+func TestCheckWritable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foreign.sqlite3")
+	sqlDB, err := sql.Open("sqlite3", path+"?_busy_timeout=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	if err := CheckWritable(sqlDB); err != nil {
+		t.Fatal(err)
+	}
+	var tables int
+	if err := sqlDB.QueryRow("select count(*) from sqlite_master").Scan(&tables); err != nil || tables != 0 {
+		t.Fatalf("got %d tables, want 0: %v", tables, err)
+	}
+
+	writer, err := sql.Open("sqlite3", path+"?_txlock=immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	tx, err := writer.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if err := CheckWritable(sqlDB); err == nil {
+		t.Fatal("locked database is reported as writable")
+	}
+}
+
+// TestDiscountUsage checks that a use is counted only if the purchase is inserted, and released if the purchase is deleted unpaid.
+func TestDiscountUsage(t *testing.T) {
+	db := openTestDB(t)
+
+	insert := func(n int) error {
+		purchase := &digitalgoods.Purchase{
+			AccessKey:   fmt.Sprintf("access-%d", n),
+			PaymentKey:  fmt.Sprintf("payment-%d", n),
+			Status:      digitalgoods.StatusNew,
+			Ordered:     digitalgoods.Order{{VariantID: "shared-a", Quantity: 1, ItemPrice: 270, ListPrice: 300, Discount: "Test10"}},
+			CreateDate:  time.Now().Format(digitalgoods.DateFmt),
+			DeleteDate:  time.Now().AddDate(0, 0, -1).Format(digitalgoods.DateFmt), // expired
+			CountryCode: "DE",
+		}
+		return db.InsertPurchase(purchase, 1)
+	}
+
+	if err := insert(0); err != nil {
+		t.Fatal(err)
+	}
+	if err := insert(1); !errors.Is(err, digitalgoods.ErrDiscountUsedUp) {
+		t.Fatalf("got %v, want %v", err, digitalgoods.ErrDiscountUsedUp)
+	}
+	if counts, _, err := db.CountPurchases(); err != nil || counts[digitalgoods.StatusNew] != 1 {
+		t.Fatalf("got purchase counts %v, want one: %v", counts, err)
+	}
+
+	// the other shop counts separately
+	if err := db.Shop("other").InsertPurchase(&digitalgoods.Purchase{AccessKey: "access-2", PaymentKey: "payment-2", Status: digitalgoods.StatusNew, Ordered: digitalgoods.Order{{VariantID: "shared-a", Quantity: 1, Discount: "Test10"}}}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+	if err := insert(3); err != nil {
+		t.Fatalf("discount code has not been released: %v", err)
+	}
+}
+
+// TestShop checks that purchases, stock and sales are scoped to the shop, even if variant IDs are equal.
+func TestShop(t *testing.T) {
+	db := openTestDB(t)
+	other := db.Shop("other")
+	catalog := testCatalog()
+
+	addToStock(t, db, "default-1")
+	addToStock(t, other, "other-1", "other-2")
+	purchase := insertPurchase(t, other, 0, 1, 0)
+	purchase.NotifyProto = "email"
+	purchase.NotifyAddr = "customer@example.com"
+	if err := other.SetNotify(purchase); err != nil {
+		t.Fatal(err)
+	}
+
+	if stock, err := db.GetStock(); err != nil || stock["shared"] != 1 {
+		t.Fatalf("got stock %v of the default shop, want 1: %v", stock, err)
+	}
+	if _, err := db.GetPurchaseByID(purchase.ID); err == nil {
+		t.Fatalf("purchase %s of the other shop is visible in the default shop", purchase.ID)
+	}
+
+	if err := other.SetSettled(purchase, catalog); err != nil {
+		t.Fatal(err)
+	}
+	if len(purchase.Delivered) != 1 || purchase.Delivered[0].Payload == "default-1" {
+		t.Fatalf("unexpected delivery: %+v", purchase.Delivered)
+	}
+	if sales, err := db.GetSales("0000-00-00"); err != nil || len(sales) != 0 {
+		t.Fatalf("sales of the other shop are visible in the default shop: %v %v", sales, err)
+	}
+
+	due, err := other.GetDueNotifications(time.Now().Unix(), 10)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("got %d due notifications in the other shop, want 1: %v", len(due), err)
+	}
+	if err := db.FailNotification(due[0].ID, "error", 0, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.DeleteNotification(due[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if again, err := other.GetDueNotifications(time.Now().Unix(), 10); err != nil || len(again) != 1 || again[0].Attempts != 0 {
+		t.Fatalf("the default shop has changed a notification of the other shop: %+v %v", again, err)
+	}
+}
+
+// TestShopSamePayload checks that two shops can stock the same payload and that delivering it in one shop leaves the other shop's stock alone.
+func TestShopSamePayload(t *testing.T) {
+	db := openTestDB(t)
+	other := db.Shop("other")
+
+	addToStock(t, db, "same")
+	addToStock(t, other, "same")
+
+	purchase := insertPurchase(t, other, 0, 1, 0)
+	if err := other.SetSettled(purchase, testCatalog()); err != nil {
+		t.Fatal(err)
+	}
+	if len(purchase.Delivered) != 1 || purchase.Delivered[0].Payload != "same" {
+		t.Fatalf("unexpected delivery: %+v", purchase.Delivered)
+	}
+	if stock, err := db.GetStock(); err != nil || stock["shared"] != 1 {
+		t.Fatalf("got stock %v of the default shop, want 1: %v", stock, err)
+	}
+	if stock, err := other.GetStock(); err != nil || stock["shared"] != 0 {
+		t.Fatalf("got stock %v of the other shop, want 0: %v", stock, err)
+	}
+}
+
+// TestMigrateStockKey checks that the stock table of an older database keeps its rows and gets the shop into its primary key.
+func TestMigrateStockKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digitalgoods.sqlite3")
+	sqlDB, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqlDB.Exec(`
+		create table stock (
+			variant text not null,
+			payload text not null primary key,
+			addtime int  not null
+		);
+		insert into stock (variant, payload, addtime) values ('shared', 'old-1', '2024-01-01');
+	`); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	addToStock(t, db.Shop("other"), "old-1")
+	if stock, err := db.GetStock(); err != nil || stock["shared"] != 1 {
+		t.Fatalf("got stock %v after the migration, want 1: %v", stock, err)
+	}
+}
@@ -0,0 +1,84 @@
+package digitalgoods
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+)
+
+var ErrDiscountUsedUp = errors.New("discount code has been used up")
+
+// Discount is a promo code. It reduces the price of each item in its scope, either by Percent or by Amount.
+type Discount struct {
+	Code       string   // case-insensitive
+	Percent    int      // 1 to 100
+	Amount     int      // euro cents off each item
+	ValidFrom  string   // yyyy-mm-dd, optional
+	ValidUntil string   // yyyy-mm-dd, inclusive, optional
+	MaxUses    int      // number of purchases, zero means unlimited
+	Brands     []string // scope, optional
+	Variants   []string // scope, optional, variant IDs
+}
+
+// ValidAt returns whether date (yyyy-mm-dd) is within the validity window of the discount.
+func (d Discount) ValidAt(date string) bool {
+	if d.ValidFrom != "" && date < d.ValidFrom {
+		return false
+	}
+	if d.ValidUntil != "" && date > d.ValidUntil {
+		return false
+	}
+	return true
+}
+
+// Applies returns whether the discount applies to the given variant of the given brand. If neither brands nor variants are specified, the discount applies to everything.
+func (d Discount) Applies(brand, variantID string) bool {
+	if len(d.Brands) == 0 && len(d.Variants) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(d.Brands, func(b string) bool { return strings.EqualFold(b, brand) }) || slices.Contains(d.Variants, variantID)
+}
+
+// Apply returns the discounted item price. It never returns less than zero.
+func (d Discount) Apply(price int) int {
+	price = price - price*d.Percent/100 - d.Amount
+	return max(price, 0)
+}
+
+type Discounts []Discount
+
+// Get returns the discount with the given code, ignoring case and surrounding whitespace.
+func (discounts Discounts) Get(code string) (Discount, bool) {
+	code = strings.TrimSpace(code)
+	for _, d := range discounts {
+		if strings.EqualFold(d.Code, code) {
+			return d, true
+		}
+	}
+	return Discount{}, false
+}
+
+// LoadDiscounts reads discounts from a JSON file. If the file does not exist, it returns no discounts.
+func LoadDiscounts(path string) (Discounts, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var discounts Discounts
+	if err := json.Unmarshal(data, &discounts); err != nil {
+		return nil, err
+	}
+	for _, d := range discounts {
+		if d.Code == "" || d.Percent < 0 || d.Percent > 100 || d.Amount < 0 || (d.Percent == 0 && d.Amount == 0) {
+			return nil, fmt.Errorf("invalid discount %q", d.Code)
+		}
+	}
+	return discounts, nil
+}
@@ -3,12 +3,14 @@ module github.com/dys2p/digitalgoods
 go 1.25.0
 
 require (
+	github.com/ProtonMail/go-crypto v1.3.0
 	github.com/alexedwards/scs/sqlite3store v0.0.0-20220216073957-c252878bcf5a
 	github.com/alexedwards/scs/v2 v2.4.0
 	github.com/dys2p/eco v0.0.0-20260106093209-59b472d3d507
 	github.com/dys2p/go-btcpay v0.8.1
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/mattn/go-sqlite3 v1.14.16
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	gitlab.com/golang-commonmark/markdown v0.0.0-20211110145824-bf3e522c626a
 	golang.org/x/crypto v0.37.0
 	golang.org/x/exp v0.0.0-20230321023759-10a507213a29
@@ -18,11 +20,11 @@ require (
 
 require (
 	github.com/abh/geoip v0.0.0-20160510155516-07cea4480daa // indirect
+	github.com/cloudflare/circl v1.6.1 // indirect
 	github.com/dys2p/go-paypal v0.2.3 // indirect
 	github.com/emersion/go-sasl v0.0.0-20220912192320-0145f2c60ead // indirect
 	github.com/emersion/go-smtp v0.16.1-0.20230108191019-90d596c5fb00 // indirect
 	github.com/kr/pretty v0.2.1 // indirect
-	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e // indirect
 	gitlab.com/golang-commonmark/html v0.0.0-20191124015941-a22733972181 // indirect
 	gitlab.com/golang-commonmark/linkify v0.0.0-20191026162114-a0c2df6c8f82 // indirect
 	gitlab.com/golang-commonmark/mdurl v0.0.0-20191124015652-932350d1cb84 // indirect
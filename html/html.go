@@ -2,17 +2,19 @@ package html
 
 import (
 	"embed"
-	"fmt"
 	"html/template"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dys2p/digitalgoods"
 	"github.com/dys2p/eco/countries"
 	"github.com/dys2p/eco/payment"
 	"github.com/dys2p/eco/ssg"
 	"gitlab.com/golang-commonmark/markdown"
+	"golang.org/x/text/language"
 )
 
 //go:embed *
@@ -20,8 +22,8 @@ var Files embed.FS
 
 var md = markdown.New(markdown.HTML(true), markdown.Linkify(false))
 
-func parse(fn ...string) *template.Template {
-	t := template.New("html").Funcs(template.FuncMap{
+func newTemplate() *template.Template {
+	return template.New("html").Funcs(template.FuncMap{
 		"AlertContextualClass": func(status digitalgoods.Status) string {
 			switch status {
 			case digitalgoods.StatusNew:
@@ -36,8 +38,11 @@ func parse(fn ...string) *template.Template {
 				return "alert-primary"
 			}
 		},
-		"FmtEuro": func(cents int) template.HTML {
-			return template.HTML(strings.Replace(fmt.Sprintf("%.2f&nbsp;€", float64(cents)/100.0), ".", ",", 1))
+		"FmtEuro": func(cents int) string {
+			return digitalgoods.FmtEuro(language.German, cents) // staff backend
+		},
+		"FmtUnix": func(unix int64) string {
+			return time.Unix(unix, 0).Format("2006-01-02 15:04") // staff backend
 		},
 		"IsURL": func(s string) bool {
 			return strings.HasPrefix(s, "https://")
@@ -46,20 +51,74 @@ func parse(fn ...string) *template.Template {
 			return template.HTML(md.RenderToString([]byte(input)))
 		},
 	})
-	t = template.Must(t.ParseFS(Files, fn...))
+}
+
+func parse(fn ...string) *template.Template {
+	t := template.Must(newTemplate().ParseFS(Files, fn...))
 	t = template.Must(t.ParseGlob(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "*.html")))
 	return t
 }
 
-var (
-	CustError    = parse("digitalgoods.proxysto.re/*.html", "customer.html", "customer/error.html")
-	CustOrder    = parse("digitalgoods.proxysto.re/*.html", "customer.html", "customer/order.html")
-	CustPurchase = parse("digitalgoods.proxysto.re/*.html", "customer.html", "customer/purchase.html")
-	CustSite     = parse("digitalgoods.proxysto.re/*.html", "customer.html")
+// Site contains the customer templates of a storefront. The site directory contains the static pages, which are rendered by ssg, the "static" directory and html files which define templates for the layout.
+type Site struct {
+	Files    fs.FS // site directory
+	Error    *template.Template
+	Order    *template.Template
+	Orders   *template.Template
+	Pages    *template.Template // base template of the static pages
+	Purchase *template.Template
+}
+
+// ParseSite parses the customer templates with the html files of the given site directory.
+func ParseSite(siteFiles fs.FS) (*Site, error) {
+	parseSite := func(fn ...string) (*template.Template, error) {
+		t, err := newTemplate().ParseFS(siteFiles, "*.html")
+		if err != nil {
+			return nil, err
+		}
+		if t, err = t.ParseFS(Files, append([]string{"customer.html"}, fn...)...); err != nil {
+			return nil, err
+		}
+		return t.ParseGlob(filepath.Join(os.Getenv("CONFIGURATION_DIRECTORY"), "*.html"))
+	}
+	var site = &Site{Files: siteFiles}
+	var err error
+	if site.Error, err = parseSite("customer/error.html"); err != nil {
+		return nil, err
+	}
+	if site.Order, err = parseSite("customer/order.html"); err != nil {
+		return nil, err
+	}
+	if site.Orders, err = parseSite("customer/orders.html"); err != nil {
+		return nil, err
+	}
+	if site.Pages, err = parseSite(); err != nil {
+		return nil, err
+	}
+	if site.Purchase, err = parseSite("customer/purchase.html"); err != nil {
+		return nil, err
+	}
+	return site, nil
+}
 
+// DefaultSite is the embedded site directory.
+var DefaultSite = func() *Site {
+	siteFiles, err := fs.Sub(Files, "digitalgoods.proxysto.re")
+	if err != nil {
+		panic(err)
+	}
+	site, err := ParseSite(siteFiles)
+	if err != nil {
+		panic(err)
+	}
+	return site
+}()
+
+var (
 	StaffError            = parse("staff.html", "staff/error.html")
 	StaffIndex            = parse("staff.html", "staff/index.html")
 	StaffLogin            = parse("staff.html", "staff/login.html")
+	StaffOutbox           = parse("staff.html", "staff/outbox.html")
 	StaffPurchase         = parse("staff.html", "staff/purchase.html")
 	StaffPurchaseNotFound = parse("staff.html", "staff/purchase-not-found.html")
 	StaffPurchaseSearch   = parse("staff.html", "staff/purchase-search.html")
@@ -85,12 +144,31 @@ type CustOrderData struct {
 	EUCountry  string
 	CountryErr bool
 	OrderErr   bool
+	QtyErrs    map[string]*QtyErr // key: variant id
+	MaxQtyErr  int                // maximum number of items per purchase, if exceeded
+
+	DiscountCode string
+	DiscountErr  string // translated
+}
+
+// QtyErr describes why the quantity of a variant can not be ordered. Message is translated and followed by Max.
+type QtyErr struct {
+	Message string
+	Max     int
+}
+
+type CustOrdersData struct {
+	TemplateData
+
+	Purchases []*digitalgoods.Purchase
 }
 
 type CustPurchaseData struct {
 	TemplateData
 
 	ActivePaymentMethod string
+	NotifyErr           string // shown at the notification form
+	NotifyProtos        map[string]bool
 	PaymentMethods      []payment.Method
 	Purchase            *digitalgoods.Purchase
 	PurchaseArticles    []digitalgoods.PurchaseArticle
@@ -102,4 +180,22 @@ type TemplateData struct {
 	FilterBrand string
 	Active      string
 	Onion       bool
+	HasOrders   bool   // customer session contains purchases
+	Title       string // of the storefront
+	BaseURL     string // of the storefront, without trailing slash
+
+	Currency        string   // selected currency for indicative prices, empty means euro only
+	CurrencyOptions []string // available currencies, empty if exchange rates are not available
+	CurrencyRate    float64  // units of Currency per euro
+}
+
+// FmtPrice formats euro cents according to the language of the template data. If a currency is selected, an indicative price in that currency is added.
+func (td TemplateData) FmtPrice(cents int) template.HTML {
+	tag := language.Make(td.Lang.Prefix)
+	result := template.HTMLEscapeString(digitalgoods.FmtEuro(tag, cents))
+	if td.Currency != "" && td.CurrencyRate > 0 {
+		indicative := digitalgoods.FmtAmount(tag, float64(cents)/100.0*td.CurrencyRate, td.Currency)
+		result += ` <small class="text-body-secondary text-nowrap">(≈&nbsp;` + template.HTMLEscapeString(indicative) + `)</small>`
+	}
+	return template.HTML(result)
 }
@@ -0,0 +1,42 @@
+package html
+
+import (
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/dys2p/digitalgoods"
+	"github.com/dys2p/eco/lang"
+)
+
+// Notification templates are plain text and define the templates "subject" and "body". They must not contain codes or purchase links, because notifications are usually not end-to-end encrypted. Codes are appended to OpenPGP-encrypted emails only.
+var Notifications = map[digitalgoods.Event]*texttemplate.Template{
+	digitalgoods.EventDelivered:          parseNotification("notify/delivered.txt"),
+	digitalgoods.EventMessage:            parseNotification("notify/message.txt"),
+	digitalgoods.EventProcessing:         parseNotification("notify/processing.txt"),
+	digitalgoods.EventRemainingDelivered: parseNotification("notify/remaining-delivered.txt"),
+	digitalgoods.EventUnderdelivered:     parseNotification("notify/underdelivered.txt"),
+}
+
+func parseNotification(fn string) *texttemplate.Template {
+	return texttemplate.Must(texttemplate.New("notify").ParseFS(Files, fn))
+}
+
+type NotifyData struct {
+	lang.Lang
+	Host     string // optional, prefixes the subject
+	Purchase *digitalgoods.Purchase
+}
+
+// RenderNotification executes the subject and body templates of a notification.
+func RenderNotification(t *texttemplate.Template, data NotifyData) (subject, body string, err error) {
+	var buf strings.Builder
+	if err := t.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", err
+	}
+	subject = strings.TrimSpace(buf.String())
+	buf.Reset()
+	if err := t.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", err
+	}
+	return subject, strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,93 @@
+// Package logging configures log/slog for digitalgoods. Request IDs are taken from the context and added to log records, and sensitive attributes are redacted.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/dys2p/digitalgoods"
+)
+
+// RequestIDHeader is read from requests, if it is set by a reverse proxy, and written to responses.
+const RequestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9\-]{1,64}$`)
+
+// Attributes with these keys are masked with digitalgoods.Mask.
+var maskedKeys = []string{"code", "payload"}
+
+// Attributes with these keys are replaced completely.
+var redactedKeys = []string{"access_key", "notify_addr", "notify_key", "password", "payment_key", "token"}
+
+type requestIDKey struct{}
+
+// RequestID returns the request ID which has been added to the context by Middleware, or an empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware adds a request ID to the request context and to the response header. It keeps a valid request ID which has been set by a reverse proxy.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			b := make([]byte, 8)
+			rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// NewHandler returns a slog.Handler which writes text records to w. Timestamps are omitted because the journal adds them.
+func NewHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	return contextHandler{slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return Redact(groups, a)
+		},
+	})}
+}
+
+// Redact masks or replaces the values of sensitive attributes. It can be used as slog.HandlerOptions.ReplaceAttr.
+func Redact(groups []string, a slog.Attr) slog.Attr {
+	switch {
+	case slices.Contains(maskedKeys, a.Key):
+		return slog.String(a.Key, digitalgoods.Mask(a.Value.String()))
+	case slices.Contains(redactedKeys, a.Key):
+		return slog.String(a.Key, "[redacted]")
+	default:
+		return a
+	}
+}
+
+// contextHandler adds the request ID from the context to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,197 @@
+// Package metrics writes metrics in the Prometheus text exposition format, without external dependencies.
+//
+// Counters and histograms are kept in memory and registered in a Registry. Values which are read from elsewhere at scrape time, like the number of purchases in the database, can be written with WriteHeader and WriteSample.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds of histogram buckets for HTTP request durations in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type Registry struct {
+	lock    sync.Mutex
+	metrics []interface{ write(io.Writer) }
+}
+
+// Write writes all registered metrics.
+func (r *Registry) Write(w io.Writer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, m := range r.metrics {
+		m.write(w)
+	}
+}
+
+func (r *Registry) register(m interface{ write(io.Writer) }) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// A CounterVec is a set of counters which are distinguished by label values.
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	lock   sync.Mutex
+	values map[string]float64 // key: label values, see key
+}
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: make(map[string]float64),
+	}
+	r.register(c)
+	return c
+}
+
+// Inc increments the counter with the given label values, which must match the label names.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.values[key(labelValues)]++
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	WriteHeader(w, c.name, c.help, "counter")
+	for _, k := range sortedKeys(c.values) {
+		WriteSample(w, c.name, labels(c.labels, k), c.values[k])
+	}
+}
+
+// A HistogramVec is a set of histograms which are distinguished by label values.
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	lock       sync.Mutex
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{
+		name:       name,
+		help:       help,
+		labels:     labels,
+		buckets:    buckets,
+		histograms: make(map[string]*histogram),
+	}
+	r.register(h)
+	return h
+}
+
+// Observe adds a value to the histogram with the given label values, which must match the label names.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	k := key(labelValues)
+	hist, ok := h.histograms[k]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.histograms[k] = hist
+	}
+	if i, _ := slices.BinarySearch(h.buckets, value); i < len(h.buckets) {
+		hist.counts[i]++
+	}
+	hist.count++
+	hist.sum += value
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	WriteHeader(w, h.name, h.help, "histogram")
+	for _, k := range sortedKeys(h.histograms) {
+		hist := h.histograms[k]
+		l := labels(h.labels, k)
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += hist.counts[i]
+			WriteSample(w, h.name+"_bucket", append(l, Label{"le", formatFloat(upper)}), float64(cumulative))
+		}
+		WriteSample(w, h.name+"_bucket", append(l, Label{"le", "+Inf"}), float64(hist.count))
+		WriteSample(w, h.name+"_sum", l, hist.sum)
+		WriteSample(w, h.name+"_count", l, float64(hist.count))
+	}
+}
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+// WriteHeader writes the HELP and TYPE lines of a metric.
+func WriteHeader(w io.Writer, name, help, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help), name, typ)
+}
+
+// WriteSample writes a sample line.
+func WriteSample(w io.Writer, name string, labels []Label, value float64) {
+	io.WriteString(w, name)
+	if len(labels) > 0 {
+		var pairs []string
+		for _, l := range labels {
+			pairs = append(pairs, l.Name+`="`+strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(l.Value)+`"`)
+		}
+		io.WriteString(w, "{"+strings.Join(pairs, ",")+"}")
+	}
+	io.WriteString(w, " "+formatFloat(value)+"\n")
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+// key joins label values with a byte which does not occur in UTF-8 text.
+func key(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func labels(names []string, key string) []Label {
+	if len(names) == 0 {
+		return nil
+	}
+	var result []Label
+	for i, value := range strings.Split(key, "\xff") {
+		result = append(result, Label{names[i], value})
+	}
+	return result
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,17 @@
+package digitalgoods
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// FmtEuro formats an amount of euro cents according to the number conventions of the given language, e.g. "1.234,50 €" in German.
+func FmtEuro(tag language.Tag, cents int) string {
+	return FmtAmount(tag, float64(cents)/100.0, "€")
+}
+
+// FmtAmount formats an amount with two decimals according to the number conventions of the given language, followed by a non-breaking space and the currency symbol or code.
+func FmtAmount(tag language.Tag, amount float64, currency string) string {
+	return message.NewPrinter(tag).Sprint(number.Decimal(amount, number.Scale(2))) + " " + currency
+}
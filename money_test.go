@@ -0,0 +1,53 @@
+package digitalgoods
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestFmtEuro(t *testing.T) {
+	var tests = []struct {
+		tag   language.Tag
+		cents int
+		want  string
+	}{
+		{language.German, 123450, "1.234,50\u00a0€"},
+		{language.German, 100000000, "1.000.000,00\u00a0€"},
+		{language.German, 5, "0,05\u00a0€"},
+		{language.German, 0, "0,00\u00a0€"},
+		{language.German, -123450, "-1.234,50\u00a0€"},
+		{language.English, 123450, "1,234.50\u00a0€"},
+		{language.English, 0, "0.00\u00a0€"},
+		{language.English, -123450, "-1,234.50\u00a0€"},
+		// no message catalog, but number conventions from CLDR
+		{language.French, 123450, "1\u00a0234,50\u00a0€"},
+		{language.French, -5, "-0,05\u00a0€"},
+		// unknown language, root conventions
+		{language.Und, 123450, "1,234.50\u00a0€"},
+	}
+	for _, test := range tests {
+		if got := FmtEuro(test.tag, test.cents); got != test.want {
+			t.Errorf("%v %d: got %q, want %q", test.tag, test.cents, got, test.want)
+		}
+	}
+}
+
+func TestFmtAmount(t *testing.T) {
+	var tests = []struct {
+		tag      language.Tag
+		amount   float64
+		currency string
+		want     string
+	}{
+		{language.German, 1234.5, "USD", "1.234,50\u00a0USD"},
+		{language.English, 1234.5, "USD", "1,234.50\u00a0USD"},
+		{language.English, 0.004, "CHF", "0.00\u00a0CHF"},
+		{language.French, -1234.5, "USD", "-1\u00a0234,50\u00a0USD"},
+	}
+	for _, test := range tests {
+		if got := FmtAmount(test.tag, test.amount, test.currency); got != test.want {
+			t.Errorf("%v %v %s: got %q, want %q", test.tag, test.amount, test.currency, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// matrix user IDs (@localpart:server) and room IDs (!opaque:server)
+var matrixAddress = regexp.MustCompile(`^[@!][^:\s]+:[A-Za-z0-9.\-]+(:[0-9]{1,5})?$`)
+
+// Matrix sends messages through the client-server API of a Matrix homeserver. Addresses are room IDs or user IDs. A direct chat is created for each user ID.
+type Matrix struct {
+	Homeserver  string       `json:"homeserver"` // base URL, e.g. https://matrix.example.org
+	AccessToken string       `json:"access-token"`
+	Client      *http.Client `json:"-"` // optional
+
+	lock   sync.Mutex
+	rooms  map[string]string // user ID -> direct chat room ID
+	userID string            // of the access token
+}
+
+// errMatrixNotFound is returned by Matrix.do if the homeserver responds with status 404, e.g. if account data has not been set.
+var errMatrixNotFound = errors.New("homeserver returned status 404")
+
+func (m *Matrix) ValidateAddress(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if len(addr) > 255 || !matrixAddress.MatchString(addr) {
+		return "", ErrInvalidAddress
+	}
+	return addr, nil
+}
+
+func (m *Matrix) Send(addr, subject, body string) error {
+	roomID := addr
+	if strings.HasPrefix(addr, "@") {
+		var err error
+		roomID, err = m.directRoom(addr)
+		if err != nil {
+			return fmt.Errorf("creating matrix room: %w", err)
+		}
+	}
+
+	txnID := make([]byte, 16)
+	if _, err := rand.Read(txnID); err != nil {
+		return fmt.Errorf("creating matrix transaction id: %w", err)
+	}
+	return m.do(http.MethodPut, "/_matrix/client/v3/rooms/"+url.PathEscape(roomID)+"/send/m.room.message/"+hex.EncodeToString(txnID), map[string]string{
+		"msgtype": "m.text",
+		"body":    subject + "\n\n" + body,
+	}, nil)
+}
+
+// directRoom returns the ID of a direct chat room with the given user. Rooms are looked up in the m.direct account data, so they are reused after a restart. If there is none, a room is created and added to m.direct.
+func (m *Matrix) directRoom(userID string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if roomID, ok := m.rooms[userID]; ok {
+		return roomID, nil
+	}
+
+	if m.userID == "" {
+		var whoami struct {
+			UserID string `json:"user_id"`
+		}
+		if err := m.do(http.MethodGet, "/_matrix/client/v3/account/whoami", nil, &whoami); err != nil {
+			return "", err
+		}
+		if whoami.UserID == "" {
+			return "", fmt.Errorf("homeserver returned no user ID")
+		}
+		m.userID = whoami.UserID
+	}
+
+	directPath := "/_matrix/client/v3/user/" + url.PathEscape(m.userID) + "/account_data/m.direct"
+	var direct = make(map[string][]string) // user ID -> room IDs
+	if err := m.do(http.MethodGet, directPath, nil, &direct); err != nil && !errors.Is(err, errMatrixNotFound) {
+		return "", fmt.Errorf("getting direct rooms: %w", err)
+	}
+
+	var roomID string
+	if rooms := direct[userID]; len(rooms) > 0 {
+		roomID = rooms[len(rooms)-1]
+	} else {
+		var resp struct {
+			RoomID string `json:"room_id"`
+		}
+		err := m.do(http.MethodPost, "/_matrix/client/v3/createRoom", map[string]any{
+			"invite":    []string{userID},
+			"is_direct": true,
+			"preset":    "trusted_private_chat",
+		}, &resp)
+		if err != nil {
+			return "", err
+		}
+		if resp.RoomID == "" {
+			return "", fmt.Errorf("homeserver returned no room ID")
+		}
+		roomID = resp.RoomID
+
+		direct[userID] = append(direct[userID], roomID)
+		if err := m.do(http.MethodPut, directPath, direct, nil); err != nil {
+			return "", fmt.Errorf("saving direct room: %w", err)
+		}
+	}
+
+	if m.rooms == nil {
+		m.rooms = make(map[string]string)
+	}
+	m.rooms[userID] = roomID
+	return roomID, nil
+}
+
+func (m *Matrix) do(method, path string, reqBody any, respBody any) error {
+	var body io.Reader
+	if reqBody != nil {
+		data, err := json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(m.Homeserver, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient(m.Client).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errMatrixNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("homeserver returned status %d", resp.StatusCode)
+	}
+	if respBody != nil {
+		return json.NewDecoder(resp.Body).Decode(respBody)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package notify_test
+
+import (
+	"testing"
+
+	"github.com/dys2p/digitalgoods/notifytest"
+)
+
+// TestMatrixDirectRoom checks that the direct room with a user is reused, also by a new notifier after a restart.
+func TestMatrixDirectRoom(t *testing.T) {
+	server := notifytest.NewServer()
+	defer server.Close()
+
+	for range 2 {
+		matrix := server.Notifiers()["matrix"] // like after a restart
+		for range 2 {
+			if err := matrix.Send("@customer:example.org", "subject", "body"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if rooms := server.MatrixRooms(); rooms != 1 {
+		t.Fatalf("got %d rooms, want 1", rooms)
+	}
+	var received int
+	for _, message := range server.Messages() {
+		if message.Proto == "matrix" && message.Addr == "@customer:example.org" {
+			received++
+		}
+	}
+	if received != 4 {
+		t.Fatalf("got %d messages, want 4", received)
+	}
+}
@@ -0,0 +1,130 @@
+// Package notify sends short messages to customers through various channels.
+//
+// Addresses are entered by customers, so each notifier validates them before they are stored.
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dys2p/eco/email"
+	"github.com/dys2p/eco/ntfysh"
+)
+
+var ErrInvalidAddress = errors.New("invalid address")
+
+// A Notifier sends messages to addresses of one channel.
+type Notifier interface {
+	// ValidateAddress returns the normalized address, or ErrInvalidAddress.
+	ValidateAddress(addr string) (string, error)
+	Send(addr, subject, body string) error
+}
+
+// Email sends plain text emails.
+type Email struct {
+	Emailer email.Emailer
+}
+
+func (e Email) ValidateAddress(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if !email.AddressValid(addr) {
+		return "", ErrInvalidAddress
+	}
+	return addr, nil
+}
+
+func (e Email) Send(addr, subject, body string) error {
+	return e.Emailer.Send(addr, subject, []byte(body))
+}
+
+// Ntfysh publishes messages to ntfy.sh topics.
+type Ntfysh struct{}
+
+func (Ntfysh) ValidateAddress(addr string) (string, error) {
+	addr = ntfysh.ValidateAddress(addr)
+	if addr == "" {
+		return "", ErrInvalidAddress
+	}
+	return addr, nil
+}
+
+func (Ntfysh) Send(addr, subject, body string) error {
+	return ntfysh.Publish(addr, subject, body)
+}
+
+// HideAddress returns an error whose message does not contain addr, not even path or query escaped, because notifier errors like *url.Error contain the address, and they are logged and shown to staff. Unwrapping returns err.
+func HideAddress(err error, addr string) error {
+	if err == nil || addr == "" {
+		return err
+	}
+	return addressError{err, addr}
+}
+
+type addressError struct {
+	err  error
+	addr string
+}
+
+func (e addressError) Error() string {
+	return strings.NewReplacer(e.addr, "[redacted]", url.PathEscape(e.addr), "[redacted]", url.QueryEscape(e.addr), "[redacted]").Replace(e.err.Error())
+}
+
+func (e addressError) Unwrap() error {
+	return e.err
+}
+
+// Config contains the optional notifiers. It is usually read from a JSON file.
+type Config struct {
+	Matrix  *Matrix  `json:"matrix"`
+	Signal  *Signal  `json:"signal"`
+	Webhook *Webhook `json:"webhook"`
+	XMPP    *XMPP    `json:"xmpp"`
+}
+
+// LoadConfig reads a Config from a JSON file. If the file does not exist, it returns an empty Config.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// Notifiers returns the configured notifiers, keyed by their protocol name.
+func (config Config) Notifiers() map[string]Notifier {
+	var notifiers = make(map[string]Notifier)
+	if config.Matrix != nil {
+		notifiers["matrix"] = config.Matrix
+	}
+	if config.Signal != nil {
+		notifiers["signal"] = config.Signal
+	}
+	if config.Webhook != nil {
+		notifiers["webhook"] = config.Webhook
+	}
+	if config.XMPP != nil {
+		notifiers["xmpp"] = config.XMPP
+	}
+	return notifiers
+}
+
+// defaultClient is used if a notifier has no client. Unlike http.DefaultClient, it has a timeout, so a slow server can't block the outbox.
+var defaultClient = &http.Client{Timeout: 30 * time.Second}
+
+func httpClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return defaultClient
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// phone numbers in E.164 format
+var signalAddress = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// Signal sends messages through the REST API of signal-cli (https://github.com/bbernhard/signal-cli-rest-api). Addresses are phone numbers in international format.
+type Signal struct {
+	URL    string       `json:"url"`    // base URL of the REST API, e.g. http://127.0.0.1:8080
+	Number string       `json:"number"` // registered sender number
+	Client *http.Client `json:"-"`      // optional
+}
+
+func (s *Signal) ValidateAddress(addr string) (string, error) {
+	addr = strings.NewReplacer(" ", "", "-", "", "/", "").Replace(addr)
+	if strings.HasPrefix(addr, "00") {
+		addr = "+" + addr[2:]
+	}
+	if !signalAddress.MatchString(addr) {
+		return "", ErrInvalidAddress
+	}
+	return addr, nil
+}
+
+func (s *Signal) Send(addr, subject, body string) error {
+	data, err := json.Marshal(map[string]any{
+		"message":    subject + "\n\n" + body,
+		"number":     s.Number,
+		"recipients": []string{addr},
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient(s.Client).Post(strings.TrimSuffix(s.URL, "/")+"/v2/send", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("signal-cli returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// SignatureHeader contains the hex-encoded HMAC-SHA256 of the request body, prefixed with "sha256=".
+const SignatureHeader = "X-Signature-256"
+
+// Webhook posts messages as JSON objects with the keys "subject" and "body" to HTTPS URLs. Requests are signed with HMAC-SHA256, see SignatureHeader.
+type Webhook struct {
+	Secret string       `json:"secret"`
+	Client *http.Client `json:"-"` // optional, the default client refuses to connect to private and loopback addresses
+}
+
+func (wh *Webhook) ValidateAddress(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if len(addr) > 1024 {
+		return "", ErrInvalidAddress
+	}
+	u, err := url.Parse(addr)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil || u.Fragment != "" {
+		return "", ErrInvalidAddress
+	}
+	return u.String(), nil
+}
+
+// Sign returns the value of SignatureHeader for the given request body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (wh *Webhook) Send(addr, subject, body string) error {
+	data, err := json.Marshal(map[string]string{
+		"subject": subject,
+		"body":    body,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, addr, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(wh.Secret, data))
+
+	client := wh.Client
+	if client == nil {
+		client = publicClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// publicClient connects to public addresses only, because webhook URLs are entered by customers.
+var publicClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Control: func(network, address string, c syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if !isPublic(net.ParseIP(host)) {
+					return errors.New("webhook address is not public")
+				}
+				return nil
+			},
+			Timeout: 10 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// nonPublicNets are special-purpose ranges which are not covered by net.IP.IsPrivate, e.g. carrier-grade NAT, see RFC 6890.
+var nonPublicNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",       // this network
+		"100.64.0.0/10",   // carrier-grade NAT
+		"192.0.0.0/24",    // IETF protocol assignments
+		"192.0.2.0/24",    // documentation
+		"198.18.0.0/15",   // benchmarking
+		"198.51.100.0/24", // documentation
+		"203.0.113.0/24",  // documentation
+		"240.0.0.0/4",     // reserved
+		"64:ff9b::/96",    // NAT64, can map to private IPv4 addresses
+		"64:ff9b:1::/48",  // local-use NAT64
+		"2001:db8::/32",   // documentation
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// isPublic returns whether ip is a global unicast address which is neither private nor in nonPublicNets.
+func isPublic(ip net.IP) bool {
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsPublic(t *testing.T) {
+	var tests = []struct {
+		ip   string
+		want bool
+	}{
+		{"1.1.1.1", true},
+		{"2a00:1450:4001:80b::200e", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"100.128.0.1", true},
+		{"0.1.2.3", false},
+		{"198.18.0.1", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:100.64.0.1", false},
+		{"64:ff9b::a00:1", false},
+	}
+	for _, test := range tests {
+		if got := isPublic(net.ParseIP(test.ip)); got != test.want {
+			t.Errorf("%s: got %t, want %t", test.ip, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package notify
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// bare JIDs, see RFC 7622
+var xmppAddress = regexp.MustCompile(`^[^"&'/:<>@\s]+@[A-Za-z0-9.\-]+$`)
+
+// XMPP sends chat messages from an XMPP account. Addresses are bare JIDs.
+//
+// It connects with direct TLS (XEP-0368) and authenticates with SASL PLAIN. A new connection is made for each message.
+type XMPP struct {
+	Addr      string      `json:"addr"` // host:port of the direct TLS endpoint, e.g. xmpp.example.org:5223
+	JID       string      `json:"jid"`  // sender, e.g. shop@example.org
+	Password  string      `json:"password"`
+	TLSConfig *tls.Config `json:"-"` // optional
+}
+
+func (x *XMPP) ValidateAddress(addr string) (string, error) {
+	addr = strings.TrimPrefix(strings.TrimSpace(addr), "xmpp:")
+	if len(addr) > 1024 || !xmppAddress.MatchString(addr) {
+		return "", ErrInvalidAddress
+	}
+	return strings.ToLower(addr), nil
+}
+
+func (x *XMPP) Send(addr, subject, body string) error {
+	username, domain, ok := strings.Cut(x.JID, "@")
+	if !ok {
+		return fmt.Errorf("invalid sender jid %q", x.JID)
+	}
+
+	tlsConfig := x.TLSConfig
+	if tlsConfig == nil {
+		host, _, _ := net.SplitHostPort(x.Addr)
+		tlsConfig = &tls.Config{ServerName: host}
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", x.Addr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	r := bufio.NewReader(conn)
+	d, err := openStream(conn, r, domain)
+	if err != nil {
+		return err
+	}
+
+	// authenticate
+	auth := base64.StdEncoding.EncodeToString([]byte("\x00" + username + "\x00" + x.Password))
+	if _, err := fmt.Fprintf(conn, `<auth xmlns="urn:ietf:params:xml:ns:xmpp-sasl" mechanism="PLAIN">%s</auth>`, auth); err != nil {
+		return err
+	}
+	result, err := nextElement(d)
+	if err != nil {
+		return err
+	}
+	if result.Name.Local != "success" {
+		return errors.New("xmpp authentication failed")
+	}
+
+	// restart stream and bind a resource
+	d, err = openStream(conn, r, domain)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(conn, `<iq type="set" id="bind"><bind xmlns="urn:ietf:params:xml:ns:xmpp-bind"/></iq>`); err != nil {
+		return err
+	}
+	iq, err := nextElement(d)
+	if err != nil {
+		return err
+	}
+	if iq.Name.Local != "iq" || attr(iq, "type") != "result" {
+		return errors.New("xmpp resource binding failed")
+	}
+	if err := d.Skip(); err != nil {
+		return err
+	}
+
+	// send message and close stream
+	if _, err := io.WriteString(conn, `<message type="chat" to="`); err != nil {
+		return err
+	}
+	xml.EscapeText(conn, []byte(addr))
+	io.WriteString(conn, `"><body>`)
+	xml.EscapeText(conn, []byte(subject+"\n\n"+body))
+	_, err = io.WriteString(conn, `</body></message></stream:stream>`)
+	return err
+}
+
+// openStream sends a stream header, reads the stream header of the server and skips its stream features. A new decoder is returned because a stream restart begins a new XML document.
+func openStream(w io.Writer, r *bufio.Reader, domain string) (*xml.Decoder, error) {
+	_, err := fmt.Fprintf(w, `<?xml version="1.0"?><stream:stream xmlns="jabber:client" xmlns:stream="http://etherx.jabber.org/streams" to="%s" version="1.0">`, domain)
+	if err != nil {
+		return nil, err
+	}
+	d := xml.NewDecoder(r) // r is an io.ByteReader, so d does not read ahead
+	stream, err := nextElement(d)
+	if err != nil {
+		return nil, err
+	}
+	if stream.Name.Local != "stream" {
+		return nil, fmt.Errorf("expected xmpp stream, got %s", stream.Name.Local)
+	}
+	features, err := nextElement(d)
+	if err != nil {
+		return nil, err
+	}
+	if features.Name.Local != "features" {
+		return nil, fmt.Errorf("expected xmpp stream features, got %s", features.Name.Local)
+	}
+	return d, d.Skip()
+}
+
+// nextElement returns the next start element. It returns an error if the server closes the stream.
+func nextElement(d *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			return token, nil
+		case xml.EndElement:
+			return xml.StartElement{}, errors.New("xmpp stream closed")
+		}
+	}
+}
+
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,243 @@
+// Package notifytest implements in-process stand-ins for the services which are used by the notifiers of package notify: a Matrix homeserver, the signal-cli REST API, a webhook receiver and an XMPP server.
+//
+// All of them record the messages they receive, so notifications can be tested offline.
+package notifytest
+
+import (
+	"crypto/hmac"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/dys2p/digitalgoods/notify"
+)
+
+const (
+	matrixToken   = "matrix-token"
+	matrixUserID  = "@shop:localhost"
+	signalNumber  = "+4915100000000"
+	webhookSecret = "webhook-secret"
+	xmppJID       = "shop@localhost"
+	xmppPassword  = "xmpp-password"
+)
+
+type Message struct {
+	Proto string
+	Addr  string
+	Text  string // subject and body
+}
+
+type Server struct {
+	lock         sync.Mutex
+	httpServer   *httptest.Server
+	xmppListener net.Listener
+	messages     []Message
+	rooms        map[string]string // room ID -> invited user
+	direct       []byte            // m.direct account data of matrixUserID
+}
+
+// NewServer starts a Server on local ports. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		rooms: make(map[string]string),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /_matrix/client/v3/account/whoami", s.matrixWhoami)
+	mux.HandleFunc("GET /_matrix/client/v3/user/{user}/account_data/m.direct", s.matrixGetDirect)
+	mux.HandleFunc("PUT /_matrix/client/v3/user/{user}/account_data/m.direct", s.matrixPutDirect)
+	mux.HandleFunc("POST /_matrix/client/v3/createRoom", s.matrixCreateRoom)
+	mux.HandleFunc("PUT /_matrix/client/v3/rooms/{room}/send/m.room.message/{txn}", s.matrixSend)
+	mux.HandleFunc("POST /signal/v2/send", s.signalSend)
+	mux.HandleFunc("POST /webhook/{name}", s.webhook)
+	s.httpServer = httptest.NewTLSServer(mux)
+
+	var err error
+	s.xmppListener, err = tls.Listen("tcp", "127.0.0.1:0", s.httpServer.TLS)
+	if err != nil {
+		panic(err)
+	}
+	go s.xmppServe()
+	return s
+}
+
+func (s *Server) Close() {
+	s.httpServer.Close()
+	s.xmppListener.Close()
+}
+
+func (s *Server) record(proto, addr, text string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.messages = append(s.messages, Message{proto, addr, text})
+}
+
+// Messages returns the messages which have been received so far.
+func (s *Server) Messages() []Message {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// MatrixRooms returns the number of rooms which have been created on the Matrix homeserver.
+func (s *Server) MatrixRooms() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.rooms)
+}
+
+// Notifiers returns notifiers which are configured to use s, keyed by their protocol name.
+func (s *Server) Notifiers() map[string]notify.Notifier {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.httpServer.Certificate())
+	return map[string]notify.Notifier{
+		"matrix": &notify.Matrix{
+			Homeserver:  s.httpServer.URL,
+			AccessToken: matrixToken,
+			Client:      s.httpServer.Client(),
+		},
+		"signal": &notify.Signal{
+			URL:    s.httpServer.URL + "/signal",
+			Number: signalNumber,
+			Client: s.httpServer.Client(),
+		},
+		"webhook": &notify.Webhook{
+			Secret: webhookSecret,
+			Client: s.httpServer.Client(),
+		},
+		"xmpp": &notify.XMPP{
+			Addr:      s.xmppListener.Addr().String(),
+			JID:       xmppJID,
+			Password:  xmppPassword,
+			TLSConfig: &tls.Config{RootCAs: pool},
+		},
+	}
+}
+
+// WebhookURL returns a webhook address which is served by s.
+func (s *Server) WebhookURL(name string) string {
+	return s.httpServer.URL + "/webhook/" + name
+}
+
+func (s *Server) matrixWhoami(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+matrixToken {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"user_id": matrixUserID})
+}
+
+func (s *Server) matrixGetDirect(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+matrixToken || r.PathValue("user") != matrixUserID {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.direct == nil {
+		http.Error(w, `{"errcode":"M_NOT_FOUND"}`, http.StatusNotFound)
+		return
+	}
+	w.Write(s.direct)
+}
+
+func (s *Server) matrixPutDirect(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+matrixToken || r.PathValue("user") != matrixUserID {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil || !json.Valid(data) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	s.lock.Lock()
+	s.direct = data
+	s.lock.Unlock()
+	w.Write([]byte("{}"))
+}
+
+func (s *Server) matrixCreateRoom(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+matrixToken {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		Invite []string `json:"invite"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Invite) != 1 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	s.lock.Lock()
+	roomID := fmt.Sprintf("!room%d:localhost", len(s.rooms))
+	s.rooms[roomID] = req.Invite[0]
+	s.lock.Unlock()
+	json.NewEncoder(w).Encode(map[string]string{"room_id": roomID})
+}
+
+// matrixSend records messages to direct chats with the address of the invited user, and other messages with the room ID.
+func (s *Server) matrixSend(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+matrixToken {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		MsgType string `json:"msgtype"`
+		Body    string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.MsgType != "m.text" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	addr := r.PathValue("room")
+	s.lock.Lock()
+	if user, ok := s.rooms[addr]; ok {
+		addr = user
+	}
+	s.lock.Unlock()
+	s.record("matrix", addr, req.Body)
+	json.NewEncoder(w).Encode(map[string]string{"event_id": "$event"})
+}
+
+func (s *Server) signalSend(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Message    string   `json:"message"`
+		Number     string   `json:"number"`
+		Recipients []string `json:"recipients"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Number != signalNumber {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	for _, recipient := range req.Recipients {
+		s.record("signal", recipient, req.Message)
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) webhook(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+	if !hmac.Equal([]byte(r.Header.Get(notify.SignatureHeader)), []byte(notify.Sign(webhookSecret, data))) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		Subject string `json:"subject"`
+		Body    string `json:"body"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	s.record("webhook", s.WebhookURL(r.PathValue("name")), strings.Join([]string{req.Subject, req.Body}, "\n\n"))
+}
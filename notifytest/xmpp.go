@@ -0,0 +1,106 @@
+package notifytest
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+const streamHeader = `<?xml version="1.0"?><stream:stream xmlns="jabber:client" xmlns:stream="http://etherx.jabber.org/streams" from="localhost" id="%s" version="1.0">`
+
+func (s *Server) xmppServe() {
+	for {
+		conn, err := s.xmppListener.Accept()
+		if err != nil {
+			return // listener closed
+		}
+		go s.xmppConn(conn)
+	}
+}
+
+// xmppConn serves the subset of XMPP which is used by notify.XMPP: SASL PLAIN, resource binding and messages.
+func (s *Server) xmppConn(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	// authentication
+	d, err := acceptStream(conn, r, "1", `<mechanisms xmlns="urn:ietf:params:xml:ns:xmpp-sasl"><mechanism>PLAIN</mechanism></mechanisms>`)
+	if err != nil {
+		return
+	}
+	var auth struct {
+		Mechanism string `xml:"mechanism,attr"`
+		Data      string `xml:",chardata"`
+	}
+	if err := decodeNext(d, &auth); err != nil {
+		return
+	}
+	credentials, _ := base64.StdEncoding.DecodeString(auth.Data)
+	username, _, _ := strings.Cut(xmppJID, "@")
+	if auth.Mechanism != "PLAIN" || string(credentials) != "\x00"+username+"\x00"+xmppPassword {
+		io.WriteString(conn, `<failure xmlns="urn:ietf:params:xml:ns:xmpp-sasl"><not-authorized/></failure></stream:stream>`)
+		return
+	}
+	io.WriteString(conn, `<success xmlns="urn:ietf:params:xml:ns:xmpp-sasl"/>`)
+
+	// resource binding
+	d, err = acceptStream(conn, r, "2", `<bind xmlns="urn:ietf:params:xml:ns:xmpp-bind"/>`)
+	if err != nil {
+		return
+	}
+	var iq struct {
+		ID string `xml:"id,attr"`
+	}
+	if err := decodeNext(d, &iq); err != nil {
+		return
+	}
+	fmt.Fprintf(conn, `<iq type="result" id="%s"><bind xmlns="urn:ietf:params:xml:ns:xmpp-bind"><jid>%s/notify</jid></bind></iq>`, iq.ID, xmppJID)
+
+	// messages until the client closes the stream
+	for {
+		var message struct {
+			To   string `xml:"to,attr"`
+			Body string `xml:"body"`
+		}
+		if err := decodeNext(d, &message); err != nil {
+			return
+		}
+		s.record("xmpp", message.To, message.Body)
+	}
+}
+
+// acceptStream reads the stream header of the client and sends a stream header and the given stream features.
+func acceptStream(w io.Writer, r *bufio.Reader, id, features string) (*xml.Decoder, error) {
+	d := xml.NewDecoder(r)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "stream" {
+			break
+		}
+	}
+	_, err := fmt.Fprintf(w, streamHeader+`<stream:features>%s</stream:features>`, id, features)
+	return d, err
+}
+
+// decodeNext decodes the next element into v. It returns io.EOF if the client closes the stream.
+func decodeNext(d *xml.Decoder, v any) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			return d.DecodeElement(v, &token)
+		case xml.EndElement:
+			return io.EOF
+		}
+	}
+}
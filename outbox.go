@@ -0,0 +1,24 @@
+package digitalgoods
+
+// Event is something customers are notified about, see html/notify.
+type Event string
+
+const (
+	EventDelivered          Event = "delivered"
+	EventMessage            Event = "message"
+	EventProcessing         Event = "processing"
+	EventRemainingDelivered Event = "remaining-delivered"
+	EventUnderdelivered     Event = "underdelivered"
+)
+
+// OutboxEntry is a notification which has not been sent yet. It refers to the purchase, so the contact data is not copied.
+type OutboxEntry struct {
+	ID         int64
+	PurchaseID string
+	Event      Event
+	Attempts   int    // failed attempts
+	NextTry    int64  // unix time
+	LastError  string // of the last failed attempt
+	Dead       bool   // delivery has been given up, see staff outbox view
+	CreateTime int64  // unix time
+}
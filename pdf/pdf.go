@@ -0,0 +1,111 @@
+// Package pdf writes simple text documents as PDF, without external dependencies.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	pageWidth  = 595 // A4 in points
+	pageHeight = 842
+	margin     = 50
+	fontSize   = 10
+	leading    = 14
+	lineChars  = 82 // monospace characters per line: (pageWidth - 2*margin) / (0.6 * fontSize)
+)
+
+// Line is a line of text. Bold lines are set in Helvetica-Bold, others in Courier.
+type Line struct {
+	Text string
+	Bold bool
+}
+
+// Write returns a PDF document with the given lines on A4 pages. Long lines are wrapped. Characters which are not in Windows-1252 are replaced by "?".
+func Write(title string, lines []Line) []byte {
+	// wrap and paginate
+	var wrapped []Line
+	for _, line := range lines {
+		runes := []rune(line.Text)
+		for len(runes) > lineChars {
+			wrapped = append(wrapped, Line{string(runes[:lineChars]), line.Bold})
+			runes = runes[lineChars:]
+		}
+		wrapped = append(wrapped, Line{string(runes), line.Bold})
+	}
+	perPage := (pageHeight - 2*margin) / leading
+	var pages [][]Line
+	for len(wrapped) > perPage {
+		pages = append(pages, wrapped[:perPage])
+		wrapped = wrapped[perPage:]
+	}
+	pages = append(pages, wrapped)
+
+	// objects: 1 catalog, 2 pages, 3 regular font, 4 bold font, 5 info, then page and content stream for each page
+	var objects []string
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 6+2*i))
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Title (%s) /Producer (digitalgoods) >>", escape(title)),
+	)
+	for i, page := range pages {
+		var content bytes.Buffer
+		content.WriteString("BT\n")
+		fmt.Fprintf(&content, "%d TL\n%d %d Td\n", leading, margin, pageHeight-margin-fontSize)
+		for _, line := range page {
+			font := "/F1"
+			if line.Bold {
+				font = "/F2"
+			}
+			fmt.Fprintf(&content, "%s %d Tf\n(%s) Tj T*\n", font, fontSize, escape(line.Text))
+		}
+		content.WriteString("ET\n")
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 7+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	var offsets []int
+	for i, object := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// escape encodes s in Windows-1252 and escapes it for a PDF string literal.
+func escape(s string) string {
+	var result strings.Builder
+	encoder := charmap.Windows1252.NewEncoder()
+	for _, r := range s {
+		b, err := encoder.Bytes([]byte(string(r)))
+		if err != nil || len(b) != 1 || b[0] < 0x20 {
+			result.WriteByte('?')
+			continue
+		}
+		switch b[0] {
+		case '(', ')', '\\':
+			result.WriteByte('\\')
+		}
+		result.WriteByte(b[0])
+	}
+	return result.String()
+}
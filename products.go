@@ -2,9 +2,11 @@ package digitalgoods
 
 import (
 	"cmp"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"iter"
+	"os"
 	"slices"
 	"strings"
 
@@ -21,10 +23,32 @@ type Variant struct {
 	OptionalFmt     string // must contain exactly one %s placeholder
 	OptionalStockID string // same stock for multiple variants
 	Price           int    // euro cents
+	Tiers           []Tier // volume pricing, optional
+	MaxQuantity     int    // per purchase, zero means unlimited
+	QRCode          bool   // show QR codes of delivered items
 	BorderTop       bool
 	WarnStock       int
 }
 
+// Tier is a volume price which applies if at least MinQuantity items of a variant are ordered.
+type Tier struct {
+	MinQuantity int
+	Price       int // euro cents
+}
+
+// PriceFor returns the item price for the given quantity, taking volume pricing tiers into account.
+func (variant Variant) PriceFor(quantity int) int {
+	var price = variant.Price
+	var minQuantity = 0
+	for _, tier := range variant.Tiers {
+		if quantity >= tier.MinQuantity && tier.MinQuantity > minQuantity {
+			price = tier.Price
+			minQuantity = tier.MinQuantity
+		}
+	}
+	return price
+}
+
 func (variant Variant) StockID() string {
 	if variant.OptionalStockID != "" {
 		return variant.OptionalStockID
@@ -64,24 +88,24 @@ func (article Article) NameHTML() template.HTML {
 	return template.HTML(article.Name)
 }
 
-// only supports langs which exist as key, TODO: language.Matcher
+// TranslateAlert returns the first non-empty alert along the fallback chain, see Fallbacks.
 func (article Article) TranslateAlert(l lang.Lang) template.HTML {
-	return template.HTML(article.Desc[l.Prefix].Alert)
+	return template.HTML(translate(article.Desc, l, func(d Description) string { return d.Alert }))
 }
 
-// only supports langs which exist as key, TODO: language.Matcher
+// TranslateAbout returns the first non-empty about text along the fallback chain, see Fallbacks.
 func (article Article) TranslateAbout(l lang.Lang) template.HTML {
-	return template.HTML(article.Desc[l.Prefix].About)
+	return template.HTML(translate(article.Desc, l, func(d Description) string { return d.About }))
 }
 
-// only supports langs which exist as key, TODO: language.Matcher
+// TranslateHowto returns the first non-empty howto text along the fallback chain, see Fallbacks.
 func (article Article) TranslateHowto(l lang.Lang) template.HTML {
-	return template.HTML(article.Desc[l.Prefix].Howto)
+	return template.HTML(translate(article.Desc, l, func(d Description) string { return d.Howto }))
 }
 
-// only supports langs which exist as key, TODO: language.Matcher
+// TranslateLegal returns the first non-empty legal text along the fallback chain, see Fallbacks.
 func (article Article) TranslateLegal(l lang.Lang) template.HTML {
-	return template.HTML(article.Desc[l.Prefix].Legal)
+	return template.HTML(translate(article.Desc, l, func(d Description) string { return d.Legal }))
 }
 
 type Category struct {
@@ -89,9 +113,9 @@ type Category struct {
 	Articles []Article
 }
 
-// only supports langs which exist as Name key, TODO: language.Matcher
+// TranslateName returns the first non-empty name along the fallback chain, see Fallbacks.
 func (cat *Category) TranslateName(l lang.Lang) template.HTML {
-	return template.HTML(cat.Name[l.Prefix])
+	return template.HTML(translate(cat.Name, l, func(name string) string { return name }))
 }
 
 type Catalog []Category
@@ -108,8 +132,20 @@ func (catalog Catalog) Articles() iter.Seq[Article] {
 	}
 }
 
-// assumes that catalog contains every article exactly once
-func (catalog Catalog) Products() []productfeed.Product {
+// SortTiers sorts the volume pricing tiers of each variant by MinQuantity, so they are displayed in ascending order.
+func (catalog Catalog) SortTiers() {
+	for article := range catalog.Articles() {
+		for _, variant := range article.Variants {
+			slices.SortFunc(variant.Tiers, func(a, b Tier) int {
+				return cmp.Compare(a.MinQuantity, b.MinQuantity)
+			})
+		}
+	}
+}
+
+// Products returns the product feed items in the given language. Availability is taken from stock. Links point to baseURL (without trailing slash).
+// It assumes that catalog contains every article exactly once.
+func (catalog Catalog) Products(stock Stock, baseURL string, l lang.Lang) []productfeed.Product {
 	var products []productfeed.Product
 	for _, category := range catalog {
 		for _, article := range category.Articles {
@@ -123,15 +159,20 @@ func (catalog Catalog) Products() []productfeed.Product {
 					imageLink = article.ImageLink // fallback
 				}
 
+				var availability = "out of stock"
+				if stock[variant.StockID()] > 0 {
+					availability = "in stock"
+				}
+
 				products = append(products, productfeed.Product{
-					Availability: "in stock",
+					Availability: availability,
 					Brand:        article.Brand,
 					Condition:    "new",
-					Description:  productfeed.HTMLtoText(article.Desc["en"].About), // TODO match request language?
+					Description:  productfeed.HTMLtoText(string(article.TranslateAbout(l))),
 					Id:           variant.ID,
 					ImageLink:    imageLink,
 					ItemGroupId:  article.ID,
-					Link:         "https://digitalgoods.proxysto.re/#" + article.ID,
+					Link:         baseURL + "/" + l.Prefix + "#" + article.ID,
 					Price:        fmt.Sprintf("%.2f EUR", float64(variant.Price)/100.0),
 					Title:        variant.Name,
 				})
@@ -154,6 +195,31 @@ func (catalog Catalog) Variant(id string) (Variant, bool) {
 	return Variant{}, false
 }
 
+// LoadCatalog reads a catalog from a JSON file. Its structure equals the Catalog type. Variant IDs must be unique and not empty.
+func LoadCatalog(path string) (Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, err
+	}
+	var ids = make(map[string]bool)
+	for article := range catalog.Articles() {
+		for _, variant := range article.Variants {
+			if variant.ID == "" {
+				return nil, fmt.Errorf("article %q: variant without id", article.ID)
+			}
+			if ids[variant.ID] {
+				return nil, fmt.Errorf("duplicate variant id %q", variant.ID)
+			}
+			ids[variant.ID] = true
+		}
+	}
+	return catalog, nil
+}
+
 type PurchaseArticle struct {
 	Article
 	Variants []PurchaseVariant // shadows Article.Variants
@@ -171,7 +237,9 @@ func (pa PurchaseArticle) AnythingDelivered() bool {
 type PurchaseVariant struct {
 	Variant
 	Quantity   int
-	GrossPrice int // in case Variant.Price has changed
+	GrossPrice int    // in case Variant.Price has changed
+	ListPrice  int    // before discount, zero if no discount has been applied
+	Discount   string // discount code
 	Delivered  []DeliveredItem
 }
 
@@ -225,6 +293,8 @@ func MakePurchaseArticles(catalog []Article, purchase *Purchase) []PurchaseArtic
 				if row.VariantID == variant.ID {
 					purchaseVariant.Variant = variant
 					purchaseVariant.GrossPrice = row.ItemPrice
+					purchaseVariant.ListPrice = row.ListPrice
+					purchaseVariant.Discount = row.Discount
 					purchaseVariant.Quantity += row.Quantity
 				}
 			}
@@ -296,3 +366,17 @@ nextItem:
 func (pv PurchaseVariant) GrossSum() int {
 	return pv.Quantity * pv.GrossPrice
 }
+
+// StockBucket returns the largest bucket which is less than or equal to quantity, so the exact stock is not disclosed. Buckets must be sorted in ascending order. If buckets is empty, quantity is returned.
+func StockBucket(quantity int, buckets []int) int {
+	if len(buckets) == 0 {
+		return quantity
+	}
+	var result = 0
+	for _, bucket := range buckets {
+		if bucket <= quantity {
+			result = bucket
+		}
+	}
+	return result
+}
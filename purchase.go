@@ -50,7 +50,7 @@ func (s Status) TranslateName(l lang.Lang) string {
 // Mask replaces all but the last six letters of a string by asterisks.
 func Mask(s string) string {
 	r := []rune(s)
-	for i := 0; i < len(s)-6; i++ {
+	for i := 0; i < len(r)-6; i++ {
 		r[i] = '*'
 	}
 	return string(r)
@@ -64,11 +64,13 @@ type Purchase struct {
 	Message     string // from store to customer, in case of problems
 	NotifyProto string
 	NotifyAddr  string
+	NotifyKey   string // armored OpenPGP public key, if NotifyProto is "openpgp"
 	Ordered     Order
 	Delivered   Delivery
 	CreateDate  string // yyyy-mm-dd, for foreign currency rates
 	DeleteDate  string // yyyy-mm-dd
 	CountryCode string // EU country
+	Lang        string // language prefix of the customer, for notifications
 }
 
 func (p *Purchase) GetUnfulfilled() (Order, error) {
@@ -125,10 +127,39 @@ func (order Order) Sum() int {
 	return sum
 }
 
+// DiscountCode returns the discount code which has been applied to the order, or an empty string.
+func (order Order) DiscountCode() string {
+	for _, row := range order {
+		if row.Discount != "" {
+			return row.Discount
+		}
+	}
+	return ""
+}
+
+// ApplyDiscount reduces the item price of the rows in the scope of the discount. The brands map has variant IDs as keys. It returns whether any row has been discounted.
+func (order Order) ApplyDiscount(d Discount, brands map[string]string) bool {
+	var applied bool
+	for i := range order {
+		if !d.Applies(brands[order[i].VariantID], order[i].VariantID) {
+			continue
+		}
+		if order[i].ListPrice == 0 {
+			order[i].ListPrice = order[i].ItemPrice
+		}
+		order[i].ItemPrice = d.Apply(order[i].ItemPrice)
+		order[i].Discount = d.Code
+		applied = true
+	}
+	return applied
+}
+
 type OrderRow struct {
 	Quantity  int    `json:"amount"`
 	VariantID string `json:"article-id"`
-	ItemPrice int    `json:"item-price"`
+	ItemPrice int    `json:"item-price"`           // actually charged, after discount
+	ListPrice int    `json:"list-price,omitempty"` // before discount, zero if no discount has been applied
+	Discount  string `json:"discount,omitempty"`   // discount code
 }
 
 type Delivery []DeliveredItem
@@ -0,0 +1,139 @@
+package digitalgoods
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dys2p/eco/lang"
+	"golang.org/x/text/language"
+)
+
+// FallbackLang is the last resort in every fallback chain.
+const FallbackLang = "en"
+
+var fallbackCache sync.Map // key: prefix + "|" + joined available keys, value: []string
+
+// Fallbacks returns the available translation keys (like "de" or "de-AT") in the order in which they should be tried for the language prefix: the prefix itself, its parent languages (de-AT → de), the best match of a language.Matcher, FallbackLang, and any other translation.
+func Fallbacks(available []string, prefix string) []string {
+	available = slices.Sorted(slices.Values(available))
+	cacheKey := prefix + "|" + strings.Join(available, ",")
+	if chain, ok := fallbackCache.Load(cacheKey); ok {
+		return chain.([]string)
+	}
+
+	var keys = make(map[string]string) // canonical tag string: key
+	var tags []language.Tag
+	for _, key := range available {
+		tag, err := language.Parse(key)
+		if err != nil {
+			continue
+		}
+		keys[tag.String()] = key
+		if key == FallbackLang {
+			tags = slices.Insert(tags, 0, tag) // the first tag is the default of the matcher
+		} else {
+			tags = append(tags, tag)
+		}
+	}
+
+	var chain []string
+	var add = func(key string) {
+		if key != "" && !slices.Contains(chain, key) {
+			chain = append(chain, key)
+		}
+	}
+
+	want, err := language.Parse(prefix)
+	if err == nil {
+		for t := want; t != language.Und; t = t.Parent() {
+			add(keys[t.String()])
+		}
+		if len(tags) > 0 {
+			_, index, confidence := language.NewMatcher(tags).Match(want)
+			if confidence != language.No {
+				add(keys[tags[index].String()])
+			}
+		}
+	} else {
+		add(keys[prefix])
+	}
+	add(keys[FallbackLang])
+	if len(tags) > 0 {
+		add(keys[tags[0].String()]) // any translation is better than none
+	}
+
+	fallbackCache.Store(cacheKey, chain)
+	return chain
+}
+
+// translate returns the first non-empty field value along the fallback chain of l.
+func translate[T any](m map[string]T, l lang.Lang, field func(T) string) string {
+	for _, key := range Fallbacks(slices.Collect(maps.Keys(m)), l.Prefix) {
+		if value := field(m[key]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+type MissingTranslation struct {
+	Lang string // prefix
+	Item string
+}
+
+// MissingTranslations returns the catalog texts which are empty in a language but not in another one. The fallback chain is not considered.
+func (catalog Catalog) MissingTranslations(langs lang.Languages) []MissingTranslation {
+	var result []MissingTranslation
+	var check = func(item string, values map[string]string) {
+		var anyValue bool
+		for _, value := range values {
+			if value != "" {
+				anyValue = true
+			}
+		}
+		if !anyValue {
+			return
+		}
+		for _, l := range langs {
+			if values[l.Prefix] == "" {
+				result = append(result, MissingTranslation{
+					Lang: l.Prefix,
+					Item: item,
+				})
+			}
+		}
+	}
+
+	for i, category := range catalog {
+		check(fmt.Sprintf("category %d (%s) name", i+1, category.Name[FallbackLang]), category.Name)
+		for _, article := range category.Articles {
+			if article.Hide {
+				continue
+			}
+			var fields = []struct {
+				name string
+				get  func(Description) string
+			}{
+				{"alert", func(d Description) string { return d.Alert }},
+				{"about", func(d Description) string { return d.About }},
+				{"howto", func(d Description) string { return d.Howto }},
+				{"legal", func(d Description) string { return d.Legal }},
+			}
+			for _, field := range fields {
+				var values = make(map[string]string)
+				for key, desc := range article.Desc {
+					values[key] = field.get(desc)
+				}
+				check(fmt.Sprintf("article %s %s", article.ID, field.name), values)
+			}
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b MissingTranslation) int {
+		return strings.Compare(a.Lang, b.Lang)
+	})
+	return result
+}
@@ -0,0 +1,72 @@
+package digitalgoods
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dys2p/eco/lang"
+	"golang.org/x/text/message"
+)
+
+func TestFallbacks(t *testing.T) {
+	var tests = []struct {
+		name      string
+		available []string
+		prefix    string
+		want      []string
+	}{
+		{"exact match", []string{"de", "en"}, "de", []string{"de", "en"}},
+		{"prefix fallback", []string{"de", "en"}, "de-AT", []string{"de", "en"}},
+		{"regional variant first", []string{"de", "de-AT", "en"}, "de-AT", []string{"de-AT", "de", "en"}},
+		{"default language", []string{"de", "en"}, "fr", []string{"en"}},
+		{"any other translation", []string{"de"}, "fr", []string{"de"}},
+		{"no translations", nil, "de", nil},
+	}
+	for _, test := range tests {
+		if got := Fallbacks(test.available, test.prefix); !slices.Equal(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	desc := map[string]Description{
+		"de": {About: "Über"},
+		"en": {About: "About", Howto: "Howto"},
+	}
+	var tests = []struct {
+		prefix string
+		field  func(Description) string
+		want   string
+	}{
+		{"de", func(d Description) string { return d.About }, "Über"},
+		{"de-AT", func(d Description) string { return d.About }, "Über"},
+		{"fr", func(d Description) string { return d.About }, "About"},
+		{"de", func(d Description) string { return d.Howto }, "Howto"}, // empty in German
+		{"de", func(d Description) string { return d.Legal }, ""},      // missing in all languages
+	}
+	for _, test := range tests {
+		if got := translate(desc, lang.Lang{Prefix: test.prefix}, test.field); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.prefix, got, test.want)
+		}
+	}
+}
+
+// TestCatalogLocales checks that each locale of the message catalog is found by its language prefix.
+func TestCatalogLocales(t *testing.T) {
+	var tests = []struct {
+		prefix string
+		want   string
+	}{
+		{"de", "Kaufen"},
+		{"en", "Buy"},
+		{"es", "Comprar"},
+		{"fr", "Acheter"},
+		{"it", "Acquista"},
+	}
+	for _, test := range tests {
+		if got := message.NewPrinter(message.MatchLanguage(test.prefix)).Sprintf("Buy"); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.prefix, got, test.want)
+		}
+	}
+}
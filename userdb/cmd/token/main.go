@@ -0,0 +1,22 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+func main() {
+
+	var token = make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		fmt.Println(err)
+		return
+	}
+	tokenHex := hex.EncodeToString(token)
+	hash := sha256.Sum256([]byte(tokenHex))
+
+	fmt.Printf("token: %s\n", tokenHex)
+	fmt.Printf("hash:  %s\n", hex.EncodeToString(hash[:]))
+}
@@ -0,0 +1,51 @@
+package userdb
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type TokenAuthenticator interface {
+	// AuthenticateToken returns the name of the token.
+	AuthenticateToken(token string) (string, error)
+}
+
+type tokendb map[string]string // name: hex-encoded sha256 hash of the token
+
+// AuthenticateToken compares the token with every hash. It does not use bcrypt because tokens are random and long.
+func (db tokendb) AuthenticateToken(token string) (string, error) {
+	if token == "" {
+		return "", ErrInvalidToken
+	}
+	hash := sha256.Sum256([]byte(token))
+	for name, storedHash := range db {
+		stored, err := hex.DecodeString(storedHash)
+		if err != nil {
+			continue
+		}
+		if subtle.ConstantTimeCompare(hash[:], stored) == 1 {
+			return name, nil
+		}
+	}
+	return "", ErrInvalidToken
+}
+
+// OpenTokens opens a JSON file which maps token names to sha256 hashes, see cmd/token.
+func OpenTokens(path string) (TokenAuthenticator, error) {
+	var db = tokendb{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		return db, json.Unmarshal(data, &db)
+	case os.IsNotExist(err):
+		return db, os.WriteFile(path, []byte("{}"), 0660)
+	default:
+		return nil, err
+	}
+}